@@ -0,0 +1,13 @@
+ephemeral "cleura_shoot_kubeconfig" "test" {
+  name     = "test-cluster"
+  project  = "project-id"
+  region   = "sto2"
+  duration = 1800
+}
+
+provider "kubernetes" {
+  host                   = ephemeral.cleura_shoot_kubeconfig.test.host
+  cluster_ca_certificate = ephemeral.cleura_shoot_kubeconfig.test.cluster_ca_certificate
+  client_certificate     = ephemeral.cleura_shoot_kubeconfig.test.client_certificate
+  client_key             = ephemeral.cleura_shoot_kubeconfig.test.client_key
+}
@@ -3,4 +3,16 @@ provider "cleura" {
   username = "username"
   token    = "yourtokenstring"
   //config_file = "/path/to/configuration/yaml"
+  //profile     = "staging"
+
+  // Used by resources and data sources that do not set them explicitly
+  //default_project = "project-id"
+  //default_region  = "sto2"
+
+  // Retry policy for requests failing with a transient error
+  //retry = {
+  //  max_attempts = 5
+  //  base_delay   = "2s"
+  //  max_delay    = "30s"
+  //}
 }
@@ -0,0 +1,2 @@
+# Worker group can be imported by specifying sequentially gardener_domain,cluster_name,region_name,project_id,worker_group_name
+terraform import cleura_shoot_worker_group.test_import gardener_domain,cluster_name,region_name,project_id,worker_group_name
@@ -0,0 +1,12 @@
+resource "cleura_shoot_worker_group" "gpu" {
+  cluster_name      = cleura_shoot_cluster.test.name
+  region            = cleura_shoot_cluster.test.region
+  project           = cleura_shoot_cluster.test.project
+  worker_group_name = "gpu001"
+  machine_type      = "b.4c16gb"
+  min_nodes         = 1
+  max_nodes         = 3
+  labels = {
+    "team" = "ml"
+  }
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultCloudProfileCacheTTL = 5 * time.Minute
+
+// cloudProfileCache keeps cloud profiles fetched from the API for the configured TTL, keyed by
+// gardener domain, so planning many clusters does not fetch the same profile for each of them.
+type cloudProfileCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]*cloudProfileCacheEntry
+}
+
+type cloudProfileCacheEntry struct {
+	// mu is held while the profile is fetched, so concurrent callers wait for the same request.
+	mu        sync.Mutex
+	profile   *cleura.CloudProfile
+	fetchedAt time.Time
+}
+
+func newCloudProfileCache(ttl time.Duration) *cloudProfileCache {
+	return &cloudProfileCache{
+		ttl:     ttl,
+		entries: make(map[string]*cloudProfileCacheEntry),
+	}
+}
+
+// get returns a copy of the cached profile of the gardener domain, calling fetch when it is missing or expired.
+func (c *cloudProfileCache) get(ctx context.Context, gardenDomain string, fetch func() (*cleura.CloudProfile, error)) (*cleura.CloudProfile, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[gardenDomain]
+	if !ok {
+		entry = &cloudProfileCacheEntry{}
+		c.entries[gardenDomain] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.profile != nil && time.Since(entry.fetchedAt) < c.ttl {
+		tflog.Debug(ctx, "Using cached cloud profile", map[string]any{"gardener_domain": gardenDomain})
+		return copyCloudProfile(entry.profile), nil
+	}
+
+	profile, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	entry.profile = profile
+	entry.fetchedAt = time.Now()
+	return copyCloudProfile(profile), nil
+}
+
+// copyCloudProfile copies the profile deep enough for callers to filter its lists without changing the cached profile.
+func copyCloudProfile(profile *cleura.CloudProfile) *cleura.CloudProfile {
+	profileCopy := *profile
+	profileCopy.Spec.Kubernetes.Versions = slices.Clone(profile.Spec.Kubernetes.Versions)
+	profileCopy.Spec.MachineTypes = slices.Clone(profile.Spec.MachineTypes)
+	profileCopy.Spec.Regions = slices.Clone(profile.Spec.Regions)
+	profileCopy.Spec.MachineImages = slices.Clone(profile.Spec.MachineImages)
+	for i, image := range profileCopy.Spec.MachineImages {
+		profileCopy.Spec.MachineImages[i].Versions = slices.Clone(image.Versions)
+	}
+	for i, region := range profileCopy.Spec.Regions {
+		profileCopy.Spec.Regions[i].Zones = slices.Clone(region.Zones)
+	}
+	return &profileCopy
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+)
+
+// testFetchCloudProfile returns a fetch function for cloudProfileCache.get counting its calls.
+func testFetchCloudProfile(name string, calls *int) func() (*cleura.CloudProfile, error) {
+	return func() (*cleura.CloudProfile, error) {
+		*calls++
+		return &cleura.CloudProfile{
+			Name: name,
+			Spec: cleura.CloudProfileSpec{
+				Regions: []cleura.CPRegion{{Name: "sto2", Zones: []cleura.CPZone{{Name: "nova"}}}},
+			},
+		}, nil
+	}
+}
+
+func TestCloudProfileCacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	cache := newCloudProfileCache(time.Hour)
+	var calls int
+
+	for range 2 {
+		if _, err := cache.get(ctx, "public", testFetchCloudProfile("cleura", &calls)); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected the cached profile to be reused, got %d fetches", calls)
+	}
+
+	// Expired profiles are fetched again.
+	cache.entries["public"].fetchedAt = time.Now().Add(-2 * time.Hour)
+	if _, err := cache.get(ctx, "public", testFetchCloudProfile("cleura", &calls)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected the expired profile to be fetched again, got %d fetches", calls)
+	}
+
+	// A zero TTL fetches the profile on every call.
+	cache = newCloudProfileCache(0)
+	calls = 0
+	for range 2 {
+		if _, err := cache.get(ctx, "public", testFetchCloudProfile("cleura", &calls)); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("expected the profile to be fetched on every call, got %d fetches", calls)
+	}
+}
+
+func TestCloudProfileCacheDomains(t *testing.T) {
+	ctx := context.Background()
+	cache := newCloudProfileCache(time.Hour)
+	var publicCalls, privateCalls int
+
+	public, err := cache.get(ctx, "public", testFetchCloudProfile("public-profile", &publicCalls))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	private, err := cache.get(ctx, "private", testFetchCloudProfile("private-profile", &privateCalls))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if publicCalls != 1 || privateCalls != 1 {
+		t.Fatalf("expected a fetch per gardener domain, got %d and %d", publicCalls, privateCalls)
+	}
+	if public.Name != "public-profile" || private.Name != "private-profile" {
+		t.Fatalf("expected the profile of each gardener domain, got %s and %s", public.Name, private.Name)
+	}
+}
+
+func TestCloudProfileCacheCopy(t *testing.T) {
+	ctx := context.Background()
+	cache := newCloudProfileCache(time.Hour)
+	var calls int
+
+	profile, err := cache.get(ctx, "public", testFetchCloudProfile("cleura", &calls))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	profile.Spec.Regions[0].Name = "changed"
+	profile.Spec.Regions[0].Zones[0].Name = "changed"
+
+	cached, err := cache.get(ctx, "public", testFetchCloudProfile("cleura", &calls))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if region := cached.Spec.Regions[0]; region.Name != "sto2" || region.Zones[0].Name != "nova" {
+		t.Fatalf("expected the cached profile to be unchanged, got region %s with zone %s", region.Name, region.Zones[0].Name)
+	}
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The validators below check planned values against the cloud profile of the gardener domain, so invalid
+// values are reported during plan instead of being rejected by the API. Null and unknown values are skipped.
+
+// validateRegion adds an error if the region is not offered by the cloud profile.
+func validateRegion(profile *cleura.CloudProfile, attributePath path.Path, region types.String, diags *diag.Diagnostics) {
+	if region.IsNull() || region.IsUnknown() {
+		return
+	}
+	var valid []string
+	for _, r := range profile.Spec.Regions {
+		valid = append(valid, r.Name)
+	}
+	if !slices.Contains(valid, region.ValueString()) {
+		addInvalidChoiceError(diags, attributePath, "Invalid Region", "Region", region.ValueString(), profile, valid)
+	}
+}
+
+// validateKubernetesVersion adds an error if the Kubernetes version is not offered by the cloud profile.
+func validateKubernetesVersion(profile *cleura.CloudProfile, attributePath path.Path, k8sVersion types.String, diags *diag.Diagnostics) {
+	if k8sVersion.IsNull() || k8sVersion.IsUnknown() || k8sVersion.ValueString() == "" {
+		return
+	}
+	var valid []string
+	for _, v := range profile.Spec.Kubernetes.Versions {
+		valid = append(valid, v.Version)
+	}
+	if !slices.Contains(valid, k8sVersion.ValueString()) {
+		addInvalidChoiceError(diags, attributePath, "Invalid Kubernetes Version", "Kubernetes version", k8sVersion.ValueString(), profile, valid)
+	}
+}
+
+// validateMachineType adds an error if the machine type is not a usable machine type of the cloud profile.
+func validateMachineType(profile *cleura.CloudProfile, attributePath path.Path, machineType types.String, diags *diag.Diagnostics) {
+	if machineType.IsNull() || machineType.IsUnknown() {
+		return
+	}
+	var valid []string
+	for _, mt := range profile.Spec.MachineTypes {
+		if mt.Usable {
+			valid = append(valid, mt.Name)
+		}
+	}
+	slices.Sort(valid)
+	if !slices.Contains(valid, machineType.ValueString()) {
+		addInvalidChoiceError(diags, attributePath, "Invalid Machine Type", "Machine type", machineType.ValueString(), profile, valid)
+	}
+}
+
+// validateMachineImage adds an error if the image, or the version of the image, is not offered by the cloud profile.
+func validateMachineImage(profile *cleura.CloudProfile, root path.Path, imageName types.String, imageVersion types.String, diags *diag.Diagnostics) {
+	if imageName.IsNull() || imageName.IsUnknown() {
+		return
+	}
+	var validNames []string
+	for _, image := range profile.Spec.MachineImages {
+		validNames = append(validNames, image.Name)
+		if image.Name != imageName.ValueString() {
+			continue
+		}
+		if imageVersion.IsNull() || imageVersion.IsUnknown() || imageVersion.ValueString() == "" {
+			return
+		}
+		var validVersions []string
+		for _, v := range image.Versions {
+			validVersions = append(validVersions, v.Version)
+		}
+		if !slices.Contains(validVersions, imageVersion.ValueString()) {
+			addInvalidChoiceError(diags, root.AtName("image_version"), "Invalid Image Version",
+				fmt.Sprintf("Version of image %q", image.Name), imageVersion.ValueString(), profile, validVersions)
+		}
+		return
+	}
+	addInvalidChoiceError(diags, root.AtName("image_name"), "Invalid Image Name", "Image", imageName.ValueString(), profile, validNames)
+}
+
+// validateZones adds an error for each zone that is not an availability zone of the region. Zones are not
+// validated if the region is not offered by the cloud profile, as that is reported for the region.
+func validateZones(profile *cleura.CloudProfile, attributePath path.Path, region types.String, zones types.List, diags *diag.Diagnostics) {
+	if region.IsNull() || region.IsUnknown() || zones.IsNull() || zones.IsUnknown() {
+		return
+	}
+	valid := regionZones(profile, region.ValueString())
+	if len(valid) == 0 {
+		return
+	}
+	for i, zone := range zones.Elements() {
+		z, ok := zone.(types.String)
+		if !ok || z.IsNull() || z.IsUnknown() {
+			continue
+		}
+		if !slices.Contains(valid, z.ValueString()) {
+			addInvalidChoiceError(diags, attributePath.AtListIndex(i), "Invalid Zone",
+				fmt.Sprintf("Zone in region %q", region.ValueString()), z.ValueString(), profile, valid)
+		}
+	}
+}
+
+// defaultVersionExpirationWarning is how long before their expiration date versions are warned about by default.
+const defaultVersionExpirationWarning = 30 * 24 * time.Hour
+
+// warnVersionLifecycle adds a warning if the version is deprecated, or expires within the warning window. A
+// zero window only warns about deprecated versions.
+func warnVersionLifecycle(versions []cleura.CPVersion, attributePath path.Path, kind string, subject string, planned types.String, window time.Duration, diags *diag.Diagnostics) {
+	if planned.IsNull() || planned.IsUnknown() {
+		return
+	}
+	idx := slices.IndexFunc(versions, func(v cleura.CPVersion) bool { return v.Version == planned.ValueString() })
+	if idx < 0 {
+		return
+	}
+	v := versions[idx]
+
+	var expiration string
+	expiresAt, err := time.Parse(time.RFC3339, v.ExpirationDate)
+	if err == nil {
+		if time.Now().After(expiresAt) {
+			expiration = fmt.Sprintf(" It expired on %s.", expiresAt.Format(time.DateOnly))
+		} else {
+			expiration = fmt.Sprintf(" It expires on %s.", expiresAt.Format(time.DateOnly))
+		}
+	}
+
+	switch {
+	case v.Classification == "deprecated":
+		diags.AddAttributeWarning(
+			attributePath,
+			"Deprecated "+kind,
+			fmt.Sprintf("%s %q is deprecated.%s Gardener upgrades the cluster once it expires, plan an upgrade to a supported version.", subject, v.Version, expiration),
+		)
+	case err == nil && window > 0 && time.Until(expiresAt) < window:
+		diags.AddAttributeWarning(
+			attributePath,
+			"Expiring "+kind,
+			fmt.Sprintf("%s %q is about to expire.%s Gardener upgrades the cluster once it expires, plan an upgrade to a supported version.", subject, v.Version, expiration),
+		)
+	}
+}
+
+func addInvalidChoiceError(diags *diag.Diagnostics, attributePath path.Path, summary string, subject string, value string, profile *cleura.CloudProfile, valid []string) {
+	diags.AddAttributeError(
+		attributePath,
+		summary,
+		fmt.Sprintf("%s %q is not available in the cloud profile %q. Valid choices are: %s.", subject, value, profile.Name, strings.Join(valid, ", ")),
+	)
+}
@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testCloudProfile is a cloud profile with a region with two zones, a region without zones and one machine image.
+var testCloudProfile = &cleura.CloudProfile{
+	Name: "cleura",
+	Spec: cleura.CloudProfileSpec{
+		Kubernetes: cleura.CPKubernetes{Versions: []cleura.CPVersion{
+			{Version: "1.31.1", Classification: "preview"},
+			{Version: "1.30.4", Classification: "supported"},
+			{Version: "1.30.2", Classification: "supported"},
+			{Version: "1.29.8", Classification: "deprecated"},
+		}},
+		MachineImages: []cleura.CPMachineImage{{
+			Name: "gardenlinux",
+			Versions: []cleura.CPVersion{
+				{Version: "1592.1.0", Classification: "supported"},
+				{Version: "1443.2.0", Classification: "deprecated"},
+			},
+		}},
+		Regions: []cleura.CPRegion{
+			{Name: "sto2", Zones: []cleura.CPZone{{Name: "nova"}, {Name: "nova-2"}}},
+			{Name: "fra1"},
+		},
+	},
+}
+
+// testErrorPaths returns the attribute paths of the errors in diags.
+func testErrorPaths(diags diag.Diagnostics) []string {
+	var paths []string
+	for _, d := range diags.Errors() {
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			paths = append(paths, withPath.Path().String())
+		}
+	}
+	return paths
+}
+
+func TestValidateZones(t *testing.T) {
+	zones := func(names ...string) types.List {
+		values := make([]attr.Value, 0, len(names))
+		for _, name := range names {
+			values = append(values, types.StringValue(name))
+		}
+		return types.ListValueMust(types.StringType, values)
+	}
+	tests := []struct {
+		name     string
+		region   types.String
+		zones    types.List
+		expected []string
+	}{
+		{name: "valid zones", region: types.StringValue("sto2"), zones: zones("nova", "nova-2")},
+		{name: "invalid zone", region: types.StringValue("sto2"), zones: zones("nova", "nova-3"), expected: []string{"zones[1]"}},
+		{name: "invalid zones", region: types.StringValue("sto2"), zones: zones("a", "b"), expected: []string{"zones[0]", "zones[1]"}},
+		{name: "region without zones", region: types.StringValue("fra1"), zones: zones("nova")},
+		{name: "unknown region", region: types.StringValue("ams1"), zones: zones("nova")},
+		{name: "null region", region: types.StringNull(), zones: zones("nova-3")},
+		{name: "null zones", region: types.StringValue("sto2"), zones: types.ListNull(types.StringType)},
+		{name: "unknown zones", region: types.StringValue("sto2"), zones: types.ListUnknown(types.StringType)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateZones(testCloudProfile, path.Root("zones"), tt.region, tt.zones, &diags)
+			got := testErrorPaths(diags)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected errors for %v, got %v", tt.expected, diags)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Fatalf("expected errors for %v, got %v", tt.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateMachineImage(t *testing.T) {
+	root := path.Root("worker_groups").AtListIndex(0)
+	tests := []struct {
+		name         string
+		imageName    types.String
+		imageVersion types.String
+		expected     string
+	}{
+		{name: "valid version", imageName: types.StringValue("gardenlinux"), imageVersion: types.StringValue("1443.2.0")},
+		{name: "no version", imageName: types.StringValue("gardenlinux"), imageVersion: types.StringNull()},
+		{name: "unknown version", imageName: types.StringValue("gardenlinux"), imageVersion: types.StringUnknown()},
+		{name: "empty version", imageName: types.StringValue("gardenlinux"), imageVersion: types.StringValue("")},
+		{name: "invalid version", imageName: types.StringValue("gardenlinux"), imageVersion: types.StringValue("934.8.0"), expected: "worker_groups[0].image_version"},
+		{name: "invalid image", imageName: types.StringValue("ubuntu"), imageVersion: types.StringValue("1443.2.0"), expected: "worker_groups[0].image_name"},
+		{name: "null image", imageName: types.StringNull(), imageVersion: types.StringValue("934.8.0")},
+		{name: "unknown image", imageName: types.StringUnknown(), imageVersion: types.StringValue("934.8.0")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateMachineImage(testCloudProfile, root, tt.imageName, tt.imageVersion, &diags)
+			got := testErrorPaths(diags)
+			if tt.expected == "" {
+				if len(got) != 0 {
+					t.Fatalf("expected no errors, got %v", diags)
+				}
+				return
+			}
+			if len(got) != 1 || got[0] != tt.expected {
+				t.Fatalf("expected error for %s, got %v", tt.expected, diags)
+			}
+		})
+	}
+}
+
+func TestWarnVersionLifecycle(t *testing.T) {
+	inDays := func(days int) string {
+		return time.Now().Add(time.Duration(days) * 24 * time.Hour).UTC().Format(time.RFC3339)
+	}
+	versions := []cleura.CPVersion{
+		{Version: "1.30.4", Classification: "supported"},
+		{Version: "1.30.2", Classification: "supported", ExpirationDate: inDays(10)},
+		{Version: "1.30.1", Classification: "supported", ExpirationDate: inDays(60)},
+		{Version: "1.29.8", Classification: "deprecated", ExpirationDate: inDays(60)},
+		{Version: "1.29.4", Classification: "deprecated"},
+	}
+	window := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		planned  types.String
+		window   time.Duration
+		expected string
+	}{
+		{name: "supported", planned: types.StringValue("1.30.4"), window: window},
+		{name: "expiring within the window", planned: types.StringValue("1.30.2"), window: window, expected: "Expiring Kubernetes Version"},
+		{name: "expiring after the window", planned: types.StringValue("1.30.1"), window: window},
+		{name: "expiring with zero window", planned: types.StringValue("1.30.2")},
+		{name: "deprecated", planned: types.StringValue("1.29.8"), window: window, expected: "Deprecated Kubernetes Version"},
+		{name: "deprecated with zero window", planned: types.StringValue("1.29.8"), expected: "Deprecated Kubernetes Version"},
+		{name: "deprecated without expiration date", planned: types.StringValue("1.29.4"), window: window, expected: "Deprecated Kubernetes Version"},
+		{name: "not offered", planned: types.StringValue("1.28.0"), window: window},
+		{name: "unknown", planned: types.StringUnknown(), window: window},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			warnVersionLifecycle(versions, path.Root("kubernetes_version"), "Kubernetes Version", "Kubernetes version", tt.planned, tt.window, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			warnings := diags.Warnings()
+			if tt.expected == "" {
+				if len(warnings) != 0 {
+					t.Fatalf("expected no warning, got %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || warnings[0].Summary() != tt.expected {
+				t.Fatalf("expected warning %q, got %v", tt.expected, warnings)
+			}
+		})
+	}
+}
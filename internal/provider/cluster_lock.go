@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// clusterKey identifies a shoot cluster across gardener domains, regions and projects.
+type clusterKey struct {
+	gardenerDomain string
+	region         string
+	project        string
+	name           string
+}
+
+// clusterLocks serializes mutating operations on the same shoot cluster within the provider process,
+// as Gardener rejects overlapping operations on a shoot with 409.
+var clusterLocks = newKeyedMutex()
+
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[clusterKey]*keyedMutexEntry
+}
+
+type keyedMutexEntry struct {
+	// ch holds a token while the lock is taken, so waiting for it can be cancelled.
+	ch      chan struct{}
+	waiters int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: make(map[clusterKey]*keyedMutexEntry)}
+}
+
+// lock blocks until the lock for key is acquired or ctx is done. The returned function releases the lock.
+func (m *keyedMutex) lock(ctx context.Context, key clusterKey) (func(), error) {
+	m.mu.Lock()
+	entry, ok := m.locks[key]
+	if !ok {
+		entry = &keyedMutexEntry{ch: make(chan struct{}, 1)}
+		m.locks[key] = entry
+	}
+	entry.waiters++
+	m.mu.Unlock()
+
+	select {
+	case entry.ch <- struct{}{}:
+		return func() {
+			<-entry.ch
+			m.release(key, entry)
+		}, nil
+	case <-ctx.Done():
+		m.release(key, entry)
+		return nil, ctx.Err()
+	}
+}
+
+func (m *keyedMutex) release(key clusterKey, entry *keyedMutexEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry.waiters--
+	if entry.waiters == 0 {
+		delete(m.locks, key)
+	}
+}
+
+// lockCluster acquires the operation lock of the cluster. The returned function releases the lock.
+func lockCluster(ctx context.Context, key clusterKey) (func(), error) {
+	tflog.Debug(ctx, "Acquiring shoot cluster operation lock", map[string]any{
+		"gardener_domain": key.gardenerDomain,
+		"region":          key.region,
+		"project":         key.project,
+		"name":            key.name,
+	})
+	return clusterLocks.lock(ctx, key)
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestKeyedMutex(t *testing.T) {
+	m := newKeyedMutex()
+	key := clusterKey{gardenerDomain: "public", region: "sto2", project: "project-id", name: "test"}
+
+	unlock, err := m.lock(context.Background(), key)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Other clusters are not blocked.
+	unlockOther, err := m.lock(context.Background(), clusterKey{gardenerDomain: "public", region: "sto2", project: "project-id", name: "other"})
+	if err != nil {
+		t.Fatalf("unexpected error locking another cluster: %s", err)
+	}
+	unlockOther()
+
+	// Waiting for a taken lock is cancelled with the context.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := m.lock(ctx, key); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %s, got %v", context.DeadlineExceeded, err)
+	}
+
+	// The lock is acquired by a waiter once it is released.
+	acquired := make(chan func())
+	go func() {
+		unlock, err := m.lock(context.Background(), key)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+			close(acquired)
+			return
+		}
+		acquired <- unlock
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("expected the lock to be held until it is released")
+	case <-time.After(10 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case unlock, ok := <-acquired:
+		if !ok {
+			t.FailNow()
+		}
+		unlock()
+	case <-time.After(time.Second):
+		t.Fatal("expected the lock to be acquired after it is released")
+	}
+
+	if len(m.locks) != 0 {
+		t.Errorf("expected released locks to be removed, got %d", len(m.locks))
+	}
+}
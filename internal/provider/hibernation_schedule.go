@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validateHibernationSchedule adds an error for each invalid attribute of the hibernation schedule, and if
+// the schedule starts and ends at the same time.
+func validateHibernationSchedule(schedule hibernationScheduleModel, root path.Path, diags *diag.Diagnostics) {
+	start, startValid := validateCronExpression(schedule.Start, root.AtName("start"), diags)
+	end, endValid := validateCronExpression(schedule.End, root.AtName("end"), diags)
+	if startValid && endValid && start == end {
+		diags.AddAttributeError(
+			root.AtName("end"),
+			"Invalid Attribute Configuration",
+			fmt.Sprintf("`start` and `end` can not be equal, the hibernation schedule starts and ends at %q.", start),
+		)
+	}
+	validateScheduleLocation(schedule.Location, root.AtName("location"), diags)
+}
+
+// validateCronExpression adds an error if the expression is not a valid cron expression. The parsed expression
+// is returned along with whether it is valid, null and unknown expressions are not.
+func validateCronExpression(expr types.String, attributePath path.Path, diags *diag.Diagnostics) (string, bool) {
+	if expr.IsNull() || expr.IsUnknown() {
+		return "", false
+	}
+	parsed, err := parseCronExpression(expr.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Cron Expression",
+			fmt.Sprintf("%q is not a valid cron expression: %s. Expected 5 fields: minute, hour, day of month, month and day of week, e.g. '00 18 * * 1-5'.", expr.ValueString(), err),
+		)
+		return "", false
+	}
+	return parsed, true
+}
+
+// validateScheduleLocation adds an error if the location is not a time zone of the IANA time zone database.
+func validateScheduleLocation(location types.String, attributePath path.Path, diags *diag.Diagnostics) {
+	if location.IsNull() || location.IsUnknown() {
+		return
+	}
+	// time.LoadLocation accepts "" and "Local" as well, which are not time zone names.
+	_, err := time.LoadLocation(location.ValueString())
+	if err != nil || location.ValueString() == "" || location.ValueString() == "Local" {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Hibernation Schedule Location",
+			fmt.Sprintf("%q is not a time zone of the IANA time zone database, e.g. 'Europe/Stockholm' or 'UTC'.", location.ValueString()),
+		)
+	}
+}
+
+// hibernationSchedulesFromResponse maps the hibernation schedules returned by the API to the schedule models.
+// Schedules without a location are read as null, as the location defaults to UTC.
+func hibernationSchedulesFromResponse(schedules []cleura.HibernationResponseSchedule) []hibernationScheduleModel {
+	var result []hibernationScheduleModel // nil if no schedules are defined
+	for _, schedule := range schedules {
+		location := types.StringNull()
+		if schedule.Location != "" {
+			location = types.StringValue(schedule.Location)
+		}
+		result = append(result, hibernationScheduleModel{
+			Start:    types.StringValue(schedule.Start),
+			End:      types.StringValue(schedule.End),
+			Location: location,
+		})
+	}
+	return result
+}
+
+// cronField describes a field of a standard 5-field cron expression.
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+	// anyValue accepts "?" in place of "*".
+	anyValue bool
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31, anyValue: true},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}},
+	// Both 0 and 7 are Sunday.
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}, anyValue: true},
+}
+
+// cronDescriptors are the predefined schedules accepted instead of the 5 fields.
+var cronDescriptors = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+// cronSyntaxError describes where a cron expression is invalid. Position is the 1-based position of the
+// invalid character in the expression.
+type cronSyntaxError struct {
+	position int
+	field    string
+	message  string
+}
+
+func (e *cronSyntaxError) Error() string {
+	if e.field == "" {
+		return fmt.Sprintf("%s at position %d", e.message, e.position)
+	}
+	return fmt.Sprintf("%s in the %s field at position %d", e.message, e.field, e.position)
+}
+
+// parseCronExpression checks that expr is a standard cron expression, as used by Gardener for hibernation
+// schedules, and returns its fields separated by a single space.
+func parseCronExpression(expr string) (string, error) {
+	var fields []string
+	var positions []int
+	for i := 0; i < len(expr); {
+		if expr[i] == ' ' || expr[i] == '\t' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(expr) && expr[i] != ' ' && expr[i] != '\t' {
+			i++
+		}
+		fields = append(fields, expr[start:i])
+		positions = append(positions, start+1)
+	}
+
+	if len(fields) == 0 {
+		return "", &cronSyntaxError{position: 1, message: "empty expression"}
+	}
+	if strings.HasPrefix(fields[0], "@") {
+		if !slices.Contains(cronDescriptors, fields[0]) {
+			return "", &cronSyntaxError{position: positions[0], message: fmt.Sprintf("unknown descriptor %q, expected one of %s", fields[0], strings.Join(cronDescriptors, ", "))}
+		}
+		if len(fields) > 1 {
+			return "", &cronSyntaxError{position: positions[1], message: "unexpected field after descriptor"}
+		}
+		return fields[0], nil
+	}
+	if len(fields) < len(cronFields) {
+		return "", &cronSyntaxError{
+			position: len(expr) + 1,
+			message:  fmt.Sprintf("expected %d fields (minute, hour, day of month, month, day of week), got %d", len(cronFields), len(fields)),
+		}
+	}
+	if len(fields) > len(cronFields) {
+		return "", &cronSyntaxError{
+			position: positions[len(cronFields)],
+			message:  fmt.Sprintf("expected %d fields (minute, hour, day of month, month, day of week), got %d", len(cronFields), len(fields)),
+		}
+	}
+
+	for i, field := range cronFields {
+		if err := parseCronField(field, fields[i], positions[i]); err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// parseCronField checks a comma separated list of values, ranges and steps of a cron field starting at position.
+func parseCronField(field cronField, value string, position int) error {
+	for _, item := range strings.Split(value, ",") {
+		if err := parseCronItem(field, item, position); err != nil {
+			return err
+		}
+		position += len(item) + 1
+	}
+	return nil
+}
+
+func parseCronItem(field cronField, item string, position int) error {
+	if item == "" {
+		return &cronSyntaxError{position: position, field: field.name, message: "empty value"}
+	}
+
+	rangePart, stepPart, hasStep := strings.Cut(item, "/")
+	if hasStep {
+		stepPosition := position + len(rangePart) + 1
+		step, err := strconv.Atoi(stepPart)
+		if err != nil || step < 1 {
+			return &cronSyntaxError{position: stepPosition, field: field.name, message: fmt.Sprintf("invalid step %q, expected a positive number", stepPart)}
+		}
+	}
+
+	if rangePart == "*" || (rangePart == "?" && field.anyValue) {
+		return nil
+	}
+
+	lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+	low, err := parseCronValue(field, lowPart, position)
+	if err != nil {
+		return err
+	}
+	if !isRange {
+		return nil
+	}
+	highPosition := position + len(lowPart) + 1
+	high, err := parseCronValue(field, highPart, highPosition)
+	if err != nil {
+		return err
+	}
+	if low > high {
+		return &cronSyntaxError{position: position, field: field.name, message: fmt.Sprintf("range start %s is greater than range end %s", lowPart, highPart)}
+	}
+	return nil
+}
+
+func parseCronValue(field cronField, value string, position int) (int, error) {
+	if value == "" {
+		return 0, &cronSyntaxError{position: position, field: field.name, message: "missing value"}
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		named, ok := field.names[strings.ToUpper(value)]
+		if !ok {
+			return 0, &cronSyntaxError{position: position, field: field.name, message: fmt.Sprintf("invalid value %q", value)}
+		}
+		return named, nil
+	}
+	if n < field.min || n > field.max {
+		return 0, &cronSyntaxError{position: position, field: field.name, message: fmt.Sprintf("value %d is out of range %d-%d", n, field.min, field.max)}
+	}
+	return n, nil
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseCronExpression(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    string
+		wantErr string
+	}{
+		{expr: "00 18 * * 1,2,3,4,5", want: "00 18 * * 1,2,3,4,5"},
+		{expr: "  0   8 * *  MON-FRI ", want: "0 8 * * MON-FRI"},
+		{expr: "*/15 0-6/2 1,15 jan-jun,DEC 0,7", want: "*/15 0-6/2 1,15 jan-jun,DEC 0,7"},
+		{expr: "30 7 ? * sat", want: "30 7 ? * sat"},
+		{expr: "5/10 * * * *", want: "5/10 * * * *"},
+		{expr: "@daily", want: "@daily"},
+		{expr: "", wantErr: "empty expression at position 1"},
+		{expr: "00 18 * *", wantErr: "expected 5 fields (minute, hour, day of month, month, day of week), got 4 at position 10"},
+		{expr: "00 18 * * 1 2", wantErr: "expected 5 fields (minute, hour, day of month, month, day of week), got 6 at position 13"},
+		{expr: "00 25 * * *", wantErr: "value 25 is out of range 0-23 in the hour field at position 4"},
+		{expr: "60 * * * *", wantErr: "value 60 is out of range 0-59 in the minute field at position 1"},
+		{expr: "0 0 0 * *", wantErr: "value 0 is out of range 1-31 in the day of month field at position 5"},
+		{expr: "0 0 * 1,13 *", wantErr: "value 13 is out of range 1-12 in the month field at position 9"},
+		{expr: "0 0 * * 1-8", wantErr: "value 8 is out of range 0-7 in the day of week field at position 11"},
+		{expr: "0 0 * * 5-1", wantErr: "range start 5 is greater than range end 1 in the day of week field at position 9"},
+		{expr: "0 0 * * MON-", wantErr: "missing value in the day of week field at position 13"},
+		{expr: "0 0 * * 1,,2", wantErr: "empty value in the day of week field at position 11"},
+		{expr: "0 0 * * FOO", wantErr: `invalid value "FOO" in the day of week field at position 9`},
+		{expr: "0 MON * * *", wantErr: `invalid value "MON" in the hour field at position 3`},
+		{expr: "*/0 * * * *", wantErr: `invalid step "0", expected a positive number in the minute field at position 3`},
+		{expr: "0 0/x * * *", wantErr: `invalid step "x", expected a positive number in the hour field at position 5`},
+		{expr: "? * * * *", wantErr: `invalid value "?" in the minute field at position 1`},
+		{expr: "@sometimes", wantErr: `unknown descriptor "@sometimes", expected one of @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly at position 1`},
+		{expr: "@daily 0", wantErr: "unexpected field after descriptor at position 8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := parseCronExpression(tt.expr)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error %q, got none", tt.wantErr)
+				}
+				if err.Error() != tt.wantErr {
+					t.Fatalf("expected error %q, got %q", tt.wantErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateHibernationSchedule(t *testing.T) {
+	root := path.Root("hibernation_schedules").AtListIndex(0)
+	tests := []struct {
+		name      string
+		schedule  hibernationScheduleModel
+		wantPaths []path.Path
+	}{
+		{
+			name: "valid",
+			schedule: hibernationScheduleModel{
+				Start:    types.StringValue("00 18 * * 1-5"),
+				End:      types.StringValue("00 08 * * 1-5"),
+				Location: types.StringValue("Europe/Stockholm"),
+			},
+		},
+		{
+			name: "unknown",
+			schedule: hibernationScheduleModel{
+				Start:    types.StringUnknown(),
+				End:      types.StringUnknown(),
+				Location: types.StringUnknown(),
+			},
+		},
+		{
+			name: "equal start and end",
+			schedule: hibernationScheduleModel{
+				Start: types.StringValue("00 18 * * 1-5"),
+				End:   types.StringValue(" 00 18  * * 1-5"),
+			},
+			wantPaths: []path.Path{root.AtName("end")},
+		},
+		{
+			name: "invalid start and location",
+			schedule: hibernationScheduleModel{
+				Start:    types.StringValue("00 18 * 1-5"),
+				End:      types.StringValue("00 08 * * 1-5"),
+				Location: types.StringValue("Europe/Nowhere"),
+			},
+			wantPaths: []path.Path{root.AtName("start"), root.AtName("location")},
+		},
+		{
+			name: "local location",
+			schedule: hibernationScheduleModel{
+				Start:    types.StringValue("00 18 * * 1-5"),
+				End:      types.StringValue("00 08 * * 1-5"),
+				Location: types.StringValue("Local"),
+			},
+			wantPaths: []path.Path{root.AtName("location")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateHibernationSchedule(tt.schedule, root, &diags)
+			if len(diags) != len(tt.wantPaths) {
+				t.Fatalf("expected %d diagnostics, got %d: %v", len(tt.wantPaths), len(diags), diags)
+			}
+			for i, d := range diags {
+				withPath, ok := d.(diag.DiagnosticWithPath)
+				if !ok || !withPath.Path().Equal(tt.wantPaths[i]) {
+					t.Errorf("expected diagnostic %d for %s, got %v", i, tt.wantPaths[i], d)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// kubeconfig is the subset of a kubeconfig file needed to connect to a cluster.
+type kubeconfig struct {
+	CurrentContext string              `yaml:"current-context"`
+	Clusters       []kubeconfigCluster `yaml:"clusters"`
+	Contexts       []kubeconfigContext `yaml:"contexts"`
+	Users          []kubeconfigUser    `yaml:"users"`
+}
+
+type kubeconfigCluster struct {
+	Name    string `yaml:"name"`
+	Cluster struct {
+		Server                   string `yaml:"server"`
+		CertificateAuthorityData string `yaml:"certificate-authority-data"`
+	} `yaml:"cluster"`
+}
+
+type kubeconfigContext struct {
+	Name    string `yaml:"name"`
+	Context struct {
+		Cluster string `yaml:"cluster"`
+		User    string `yaml:"user"`
+	} `yaml:"context"`
+}
+
+type kubeconfigUser struct {
+	Name string `yaml:"name"`
+	User struct {
+		ClientCertificateData string `yaml:"client-certificate-data"`
+		ClientKeyData         string `yaml:"client-key-data"`
+		Token                 string `yaml:"token"`
+	} `yaml:"user"`
+}
+
+// kubeconfigCredentials are the connection details of the current context of a kubeconfig. Certificates and
+// keys are PEM encoded, as expected by e.g. the kubernetes and helm providers. Values not present in the
+// kubeconfig are empty.
+type kubeconfigCredentials struct {
+	CurrentContext       string
+	Host                 string
+	ClusterCACertificate string
+	ClientCertificate    string
+	ClientKey            string
+	Token                string
+}
+
+// kubeconfigModel are the attributes of a generated kubeconfig, embedded in the models of the
+// cleura_shoot_kubeconfig resource and ephemeral resource.
+type kubeconfigModel struct {
+	Config               types.String `tfsdk:"config"`
+	CurrentContext       types.String `tfsdk:"current_context"`
+	Host                 types.String `tfsdk:"host"`
+	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	ClientCertificate    types.String `tfsdk:"client_certificate"`
+	ClientKey            types.String `tfsdk:"client_key"`
+	Token                types.String `tfsdk:"token"`
+	ExpiresAt            types.String `tfsdk:"expires_at"`
+}
+
+// setKubeconfig sets config to the kubeconfig, the connection attributes to the credentials of its current
+// context and expires_at to the expiry of its client certificate. Kubeconfigs without client certificate
+// expire duration seconds after generatedAt. It returns when the kubeconfig expires.
+func (m *kubeconfigModel) setKubeconfig(data []byte, generatedAt time.Time, duration int64) (time.Time, error) {
+	credentials, err := parseKubeconfig(data)
+	if err != nil {
+		return time.Time{}, err
+	}
+	expiresAt := generatedAt.Add(time.Duration(duration) * time.Second)
+	if credentials.ClientCertificate != "" {
+		if expiresAt, err = certificateNotAfter(credentials.ClientCertificate); err != nil {
+			return time.Time{}, fmt.Errorf("could not read the expiry of the client certificate: %w", err)
+		}
+	}
+
+	m.Config = types.StringValue(string(data))
+	m.CurrentContext = types.StringValue(credentials.CurrentContext)
+	m.Host = stringValueOrNull(credentials.Host)
+	m.ClusterCACertificate = stringValueOrNull(credentials.ClusterCACertificate)
+	m.ClientCertificate = stringValueOrNull(credentials.ClientCertificate)
+	m.ClientKey = stringValueOrNull(credentials.ClientKey)
+	m.Token = stringValueOrNull(credentials.Token)
+	m.ExpiresAt = types.StringValue(expiresAt.UTC().Format(time.RFC3339))
+	return expiresAt, nil
+}
+
+// parseKubeconfig returns the credentials of the current context of the kubeconfig. A kubeconfig without
+// current context is accepted if it has a single context.
+func parseKubeconfig(data []byte) (*kubeconfigCredentials, error) {
+	var config kubeconfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	ctx, err := config.currentContext()
+	if err != nil {
+		return nil, err
+	}
+	contextName := ctx.Name
+	cluster, ok := findByName(config.Clusters, ctx.Context.Cluster, func(c kubeconfigCluster) string { return c.Name })
+	if !ok {
+		return nil, fmt.Errorf("cluster %q of context %q not found in kubeconfig", ctx.Context.Cluster, contextName)
+	}
+
+	credentials := &kubeconfigCredentials{
+		CurrentContext: contextName,
+		Host:           cluster.Cluster.Server,
+	}
+	if credentials.ClusterCACertificate, err = decodeKubeconfigData("certificate-authority-data", cluster.Cluster.CertificateAuthorityData); err != nil {
+		return nil, err
+	}
+
+	// The user is optional, e.g. for clusters allowing anonymous access
+	if ctx.Context.User == "" {
+		return credentials, nil
+	}
+	user, ok := findByName(config.Users, ctx.Context.User, func(u kubeconfigUser) string { return u.Name })
+	if !ok {
+		return nil, fmt.Errorf("user %q of context %q not found in kubeconfig", ctx.Context.User, contextName)
+	}
+	if credentials.ClientCertificate, err = decodeKubeconfigData("client-certificate-data", user.User.ClientCertificateData); err != nil {
+		return nil, err
+	}
+	if credentials.ClientKey, err = decodeKubeconfigData("client-key-data", user.User.ClientKeyData); err != nil {
+		return nil, err
+	}
+	credentials.Token = user.User.Token
+
+	return credentials, nil
+}
+
+// currentContext returns the current context of the kubeconfig, or its only context if it has no current
+// context.
+func (c *kubeconfig) currentContext() (kubeconfigContext, error) {
+	contextName := c.CurrentContext
+	if contextName == "" {
+		if len(c.Contexts) != 1 {
+			return kubeconfigContext{}, errors.New("kubeconfig has no current-context")
+		}
+		contextName = c.Contexts[0].Name
+	}
+	ctx, ok := findByName(c.Contexts, contextName, func(c kubeconfigContext) string { return c.Name })
+	if !ok {
+		return kubeconfigContext{}, fmt.Errorf("context %q not found in kubeconfig", contextName)
+	}
+	return ctx, nil
+}
+
+func findByName[T any](items []T, name string, nameOf func(T) string) (T, bool) {
+	for _, item := range items {
+		if nameOf(item) == name {
+			return item, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// decodeKubeconfigData decodes a base64 encoded *-data field of a kubeconfig.
+func decodeKubeconfigData(field string, value string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s in kubeconfig: %w", field, err)
+	}
+	return string(decoded), nil
+}
+
+// certificateNotAfter returns the time the first certificate of the PEM encoded data expires.
+func certificateNotAfter(certificatePEM string) (time.Time, error) {
+	block, _ := pem.Decode([]byte(certificatePEM))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return time.Time{}, errors.New("no PEM encoded certificate found")
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid certificate: %w", err)
+	}
+	return certificate.NotAfter, nil
+}
@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// kubeconfigFileMode is the permission of kubeconfig files written by the provider, as they contain credentials.
+const kubeconfigFileMode = 0o600
+
+// kubeconfigEntries are the cluster, user and context entries of a kubeconfig file for one context. User is
+// nil for contexts without user.
+type kubeconfigEntries struct {
+	cluster map[string]any
+	user    map[string]any
+	context map[string]any
+}
+
+// newKubeconfigEntries returns the entries of the current context of the kubeconfig, renamed to contextName.
+// Fields not known to the provider, e.g. the namespace of the context, are kept.
+func newKubeconfigEntries(data []byte, contextName string) (*kubeconfigEntries, error) {
+	var config kubeconfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+	ctx, err := config.currentContext()
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	cluster, ok := findKubeconfigEntry(raw, "clusters", ctx.Context.Cluster)
+	if !ok {
+		return nil, fmt.Errorf("cluster %q of context %q not found in kubeconfig", ctx.Context.Cluster, ctx.Name)
+	}
+	contextEntry, _ := findKubeconfigEntry(raw, "contexts", ctx.Name)
+	contextFields, _ := contextEntry["context"].(map[string]any)
+	if contextFields == nil {
+		contextFields = map[string]any{}
+	}
+	contextFields["cluster"] = contextName
+
+	entries := &kubeconfigEntries{
+		cluster: map[string]any{"name": contextName, "cluster": cluster["cluster"]},
+		context: map[string]any{"name": contextName, "context": contextFields},
+	}
+	if ctx.Context.User != "" {
+		user, ok := findKubeconfigEntry(raw, "users", ctx.Context.User)
+		if !ok {
+			return nil, fmt.Errorf("user %q of context %q not found in kubeconfig", ctx.Context.User, ctx.Name)
+		}
+		entries.user = map[string]any{"name": contextName, "user": user["user"]}
+		contextFields["user"] = contextName
+	}
+	return entries, nil
+}
+
+// writeKubeconfigContext merges the current context of the kubeconfig into the kubeconfig file as
+// contextName, replacing the context, cluster and user of that name. Other entries of the file are kept, and
+// the current context of the file is only set if it has none. The file is created if it does not exist.
+func writeKubeconfigContext(filename string, contextName string, data []byte) error {
+	entries, err := newKubeconfigEntries(data, contextName)
+	if err != nil {
+		return err
+	}
+	file, err := readKubeconfigFile(filename)
+	if err != nil {
+		return err
+	}
+
+	removeKubeconfigEntries(file, contextName)
+	file["clusters"] = append(kubeconfigEntryList(file, "clusters"), entries.cluster)
+	file["contexts"] = append(kubeconfigEntryList(file, "contexts"), entries.context)
+	if entries.user != nil {
+		file["users"] = append(kubeconfigEntryList(file, "users"), entries.user)
+	}
+	if file["apiVersion"] == nil {
+		file["apiVersion"] = "v1"
+	}
+	if file["kind"] == nil {
+		file["kind"] = "Config"
+	}
+	if currentContext, _ := file["current-context"].(string); currentContext == "" {
+		file["current-context"] = contextName
+	}
+	return writeKubeconfigFile(filename, file)
+}
+
+// removeKubeconfigContext removes the context, cluster and user named contextName from the kubeconfig file,
+// if they still hold the credentials of the kubeconfig. Entries written for a newer kubeconfig, e.g. by a
+// resource replacing this one, are kept. The file is kept even if it has no contexts left, as it may hold
+// other settings, e.g. preferences.
+func removeKubeconfigContext(filename string, contextName string, data []byte) error {
+	entries, err := newKubeconfigEntries(data, contextName)
+	if err != nil {
+		return err
+	}
+	file, err := readKubeconfigFile(filename)
+	if err != nil {
+		return err
+	}
+	if !hasKubeconfigCredentials(file, contextName, entries) {
+		return nil
+	}
+
+	removeKubeconfigEntries(file, contextName)
+	if currentContext, _ := file["current-context"].(string); currentContext == contextName {
+		delete(file, "current-context")
+	}
+	return writeKubeconfigFile(filename, file)
+}
+
+// kubeconfigContextWritten reports whether the kubeconfig file has the context named contextName, holding the
+// credentials of the kubeconfig. It reports false if the file does not exist.
+func kubeconfigContextWritten(filename string, contextName string, data []byte) (bool, error) {
+	entries, err := newKubeconfigEntries(data, contextName)
+	if err != nil {
+		return false, err
+	}
+	file, err := readKubeconfigFile(filename)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := findKubeconfigEntry(file, "contexts", contextName); !ok {
+		return false, nil
+	}
+	return hasKubeconfigCredentials(file, contextName, entries), nil
+}
+
+// hasKubeconfigCredentials reports whether the cluster and user named contextName of the kubeconfig file
+// are the ones of entries.
+func hasKubeconfigCredentials(file map[string]any, contextName string, entries *kubeconfigEntries) bool {
+	cluster, _ := findKubeconfigEntry(file, "clusters", contextName)
+	user, _ := findKubeconfigEntry(file, "users", contextName)
+	return reflect.DeepEqual(cluster, entries.cluster) && (entries.user == nil || reflect.DeepEqual(user, entries.user))
+}
+
+// readKubeconfigFile reads the kubeconfig file, which is empty if it does not exist.
+func readKubeconfigFile(filename string) (map[string]any, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]any{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	file := map[string]any{}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig %s: %w", filename, err)
+	}
+	if file == nil {
+		// The file is empty.
+		file = map[string]any{}
+	}
+	return file, nil
+}
+
+// writeKubeconfigFile replaces the kubeconfig file, so readers never see a partially written file.
+func writeKubeconfigFile(filename string, file map[string]any) error {
+	data, err := yaml.Marshal(file)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(filename)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+	// Removing the temporary file fails once it has been renamed, which is expected.
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if err := tmp.Chmod(kubeconfigFileMode); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+// removeKubeconfigEntries removes the cluster, user and context named name from the kubeconfig file. Lists
+// left empty are written as [], not null.
+func removeKubeconfigEntries(file map[string]any, name string) {
+	for _, key := range []string{"clusters", "users", "contexts"} {
+		kept := []any{}
+		for _, entry := range kubeconfigEntryList(file, key) {
+			if entryMap, _ := entry.(map[string]any); entryMap["name"] != name {
+				kept = append(kept, entry)
+			}
+		}
+		file[key] = kept
+	}
+}
+
+// findKubeconfigEntry returns the entry named name of the clusters, users or contexts of a kubeconfig.
+func findKubeconfigEntry(file map[string]any, key string, name string) (map[string]any, bool) {
+	for _, entry := range kubeconfigEntryList(file, key) {
+		if entryMap, _ := entry.(map[string]any); entryMap != nil && entryMap["name"] == name {
+			return entryMap, true
+		}
+	}
+	return nil, false
+}
+
+func kubeconfigEntryList(file map[string]any, key string) []any {
+	list, _ := file[key].([]any)
+	return list
+}
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testExistingKubeconfig is a kubeconfig file of another cluster the generated kubeconfig is merged into.
+const testExistingKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: other
+  cluster:
+    server: https://api.other.example.com
+contexts:
+- name: other
+  context:
+    cluster: other
+    user: other
+    namespace: default
+current-context: other
+users:
+- name: other
+  user:
+    token: other-token
+`
+
+func TestWriteKubeconfigContext(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "kube", "config")
+
+	if err := writeKubeconfigContext(filename, "test", []byte(testKubeconfig)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	info, err := os.Stat(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != kubeconfigFileMode {
+		t.Errorf("expected permissions %o, got %o", kubeconfigFileMode, info.Mode().Perm())
+	}
+	got := readTestKubeconfig(t, filename)
+	want := kubeconfigCredentials{
+		CurrentContext:       "test",
+		Host:                 "https://api.test.abc123.gardener.cleura.cloud",
+		ClusterCACertificate: testCACertificate,
+		ClientCertificate:    testClientCertificate,
+		ClientKey:            testClientKey,
+	}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestWriteKubeconfigContextMerge(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(filename, []byte(testExistingKubeconfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writing twice, as on renewal, replaces the context instead of adding it again.
+	for range 2 {
+		if err := writeKubeconfigContext(filename, "test", []byte(testTokenKubeconfig)); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	file, err := readKubeconfigFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"clusters", "users", "contexts"} {
+		if n := len(kubeconfigEntryList(file, key)); n != 2 {
+			t.Errorf("expected 2 %s, got %d", key, n)
+		}
+	}
+	if file["current-context"] != "other" {
+		t.Errorf("expected current-context other to be kept, got %v", file["current-context"])
+	}
+	other, _ := findKubeconfigEntry(file, "contexts", "other")
+	if namespace := testKubeconfigEntryFields(other, "context")["namespace"]; namespace != "default" {
+		t.Errorf("expected namespace of context other to be kept, got %v", namespace)
+	}
+	test, _ := findKubeconfigEntry(file, "contexts", "test")
+	if context := testKubeconfigEntryFields(test, "context"); context["cluster"] != "test" || context["user"] != "test" {
+		t.Errorf("expected context test to use cluster and user test, got %v", context)
+	}
+	user, _ := findKubeconfigEntry(file, "users", "test")
+	if token := testKubeconfigEntryFields(user, "user")["token"]; token != "secret-token" {
+		t.Errorf("expected token of user test, got %v", token)
+	}
+}
+
+func TestWriteKubeconfigContextInvalidFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(filename, []byte("clusters: ["), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err := writeKubeconfigContext(filename, "test", []byte(testKubeconfig))
+	if err == nil || !strings.HasPrefix(err.Error(), "invalid kubeconfig "+filename) {
+		t.Fatalf("expected invalid kubeconfig error, got %v", err)
+	}
+}
+
+func TestRemoveKubeconfigContext(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(filename, []byte(testExistingKubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeKubeconfigContext(filename, "test", []byte(testTokenKubeconfig)); err != nil {
+		t.Fatal(err)
+	}
+
+	// A context renewed with other credentials, e.g. by a replacing resource, is kept.
+	if err := removeKubeconfigContext(filename, "test", []byte(testKubeconfig)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	file, err := readKubeconfigFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := findKubeconfigEntry(file, "contexts", "test"); !ok {
+		t.Fatal("expected context test to be kept")
+	}
+
+	if err := removeKubeconfigContext(filename, "test", []byte(testTokenKubeconfig)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	file, err = readKubeconfigFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"clusters", "users", "contexts"} {
+		if _, ok := findKubeconfigEntry(file, key, "test"); ok {
+			t.Errorf("expected test to be removed from %s", key)
+		}
+		if _, ok := findKubeconfigEntry(file, key, "other"); !ok {
+			t.Errorf("expected other to be kept in %s", key)
+		}
+	}
+	if file["current-context"] != "other" {
+		t.Errorf("expected current-context other to be kept, got %v", file["current-context"])
+	}
+
+	// The file is kept with empty lists when its last context is removed.
+	if err := removeKubeconfigContext(filename, "other", []byte(testExistingKubeconfig)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("expected %s to be kept, got %v", filename, err)
+	}
+	for _, key := range []string{"clusters", "users", "contexts"} {
+		if !strings.Contains(string(data), key+": []") {
+			t.Errorf("expected empty %s in %s", key, data)
+		}
+	}
+
+	// Removing from a missing file succeeds.
+	if err := removeKubeconfigContext(filepath.Join(t.TempDir(), "missing"), "test", []byte(testKubeconfig)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestKubeconfigContextWritten(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config")
+
+	written, err := kubeconfigContextWritten(filename, "test", []byte(testKubeconfig))
+	if err != nil || written {
+		t.Fatalf("expected missing file not to be written, got %t, %v", written, err)
+	}
+
+	if err := os.WriteFile(filename, []byte(testExistingKubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeKubeconfigContext(filename, "test", []byte(testKubeconfig)); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		contextName string
+		data        string
+		expected    bool
+	}{
+		{name: "written", contextName: "test", data: testKubeconfig, expected: true},
+		{name: "other credentials", contextName: "test", data: testTokenKubeconfig, expected: false},
+		{name: "missing context", contextName: "missing", data: testKubeconfig, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			written, err := kubeconfigContextWritten(filename, tt.contextName, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if written != tt.expected {
+				t.Fatalf("expected %t, got %t", tt.expected, written)
+			}
+		})
+	}
+}
+
+// readTestKubeconfig returns the credentials of the current context of the kubeconfig file.
+func readTestKubeconfig(t *testing.T, filename string) kubeconfigCredentials {
+	t.Helper()
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	credentials, err := parseKubeconfig(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *credentials
+}
+
+// testKubeconfigEntryFields returns the fields of a cluster, user or context entry, e.g. the user of a user entry.
+func testKubeconfigEntryFields(entry map[string]any, key string) map[string]any {
+	fields, _ := entry[key].(map[string]any)
+	return fields
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// pollingSchedule defines how often the waiters poll a shoot cluster while an operation is in progress.
+type pollingSchedule struct {
+	// initialDelay before the first poll, giving Gardener time to start the operation.
+	initialDelay time.Duration
+	// interval between the first polls, growing up to maxInterval.
+	interval    time.Duration
+	maxInterval time.Duration
+}
+
+type pollingModel struct {
+	InitialDelay types.String `tfsdk:"initial_delay"`
+	Interval     types.String `tfsdk:"interval"`
+	MaxInterval  types.String `tfsdk:"max_interval"`
+}
+
+func defaultPollingSchedule() pollingSchedule {
+	return pollingSchedule{
+		initialDelay: 120 * time.Second,
+		interval:     30 * time.Second,
+		maxInterval:  75 * time.Second,
+	}
+}
+
+// pollingAttributeDescriptions describes the attributes of the polling block of the provider and the resources.
+var pollingAttributeDescriptions = map[string]string{
+	"initial_delay": "Delay before the cluster is polled for the first time after an operation is started. Defaults to '120s'.",
+	"interval":      "Interval between the first polls, growing with each poll up to `max_interval`. Defaults to '30s'.",
+	"max_interval":  "Maximum interval between two polls. Defaults to '75s'.",
+}
+
+// newPollingSchedule returns the polling schedule configured in the polling attribute at root, using
+// base for everything that is not set. Unknown values are left to be validated once they are known.
+func newPollingSchedule(config *pollingModel, base pollingSchedule, root path.Path, diags *diag.Diagnostics) pollingSchedule {
+	schedule := base
+	if config == nil {
+		return schedule
+	}
+
+	parsePollingDuration(root.AtName("initial_delay"), config.InitialDelay, &schedule.initialDelay, diags)
+	parsePollingDuration(root.AtName("interval"), config.Interval, &schedule.interval, diags)
+	parsePollingDuration(root.AtName("max_interval"), config.MaxInterval, &schedule.maxInterval, diags)
+
+	if schedule.interval == 0 {
+		diags.AddAttributeError(root.AtName("interval"), "Invalid Polling Configuration", "`interval` must be greater than zero.")
+	}
+	if schedule.interval > schedule.maxInterval {
+		diags.AddAttributeError(
+			root,
+			"Invalid Polling Configuration",
+			fmt.Sprintf("`interval` (%s) can not be greater than `max_interval` (%s).", schedule.interval, schedule.maxInterval),
+		)
+	}
+	return schedule
+}
+
+// validatePollingConfig validates the polling attribute at root on its own, as the provider
+// configuration it falls back to is not known during validation.
+func validatePollingConfig(config *pollingModel, root path.Path, diags *diag.Diagnostics) {
+	if config == nil {
+		return
+	}
+	var schedule pollingSchedule
+	parsePollingDuration(root.AtName("initial_delay"), config.InitialDelay, &schedule.initialDelay, diags)
+	intervalSet := parsePollingDuration(root.AtName("interval"), config.Interval, &schedule.interval, diags)
+	parsePollingDuration(root.AtName("max_interval"), config.MaxInterval, &schedule.maxInterval, diags)
+
+	if intervalSet && schedule.interval == 0 {
+		diags.AddAttributeError(root.AtName("interval"), "Invalid Polling Configuration", "`interval` must be greater than zero.")
+	}
+	if schedule.interval > 0 && schedule.maxInterval > 0 && schedule.interval > schedule.maxInterval {
+		diags.AddAttributeError(
+			root,
+			"Invalid Polling Configuration",
+			fmt.Sprintf("`interval` (%s) can not be greater than `max_interval` (%s).", schedule.interval, schedule.maxInterval),
+		)
+	}
+}
+
+// parsePollingDuration parses a known duration value into target and reports whether it was set.
+func parsePollingDuration(attributePath path.Path, value types.String, target *time.Duration, diags *diag.Diagnostics) bool {
+	if value.IsNull() || value.IsUnknown() {
+		return false
+	}
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil || duration < 0 {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Polling Configuration",
+			fmt.Sprintf("Expected a non-negative duration such as '30s', got: %q.", value.ValueString()),
+		)
+		return false
+	}
+	*target = duration
+	return true
+}
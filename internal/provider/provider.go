@@ -2,13 +2,21 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
 	cf "github.com/aztekas/cleura-client-go/pkg/configfile"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -20,7 +28,8 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &cleuraProvider{}
+	_ provider.Provider                       = &cleuraProvider{}
+	_ provider.ProviderWithEphemeralResources = &cleuraProvider{}
 )
 
 type cleuraProviderModel struct {
@@ -28,6 +37,37 @@ type cleuraProviderModel struct {
 	Username   types.String `tfsdk:"username"`
 	Token      types.String `tfsdk:"token"`
 	ConfigFile types.String `tfsdk:"config_file"`
+	Profile    types.String `tfsdk:"profile"`
+
+	DefaultProject        types.String `tfsdk:"default_project"`
+	DefaultRegion         types.String `tfsdk:"default_region"`
+	DefaultGardenerDomain types.String `tfsdk:"default_gardener_domain"`
+
+	DefaultDeletionProtection types.Bool `tfsdk:"default_deletion_protection"`
+
+	Retry                *retryModel   `tfsdk:"retry"`
+	CloudProfileCacheTTL types.String  `tfsdk:"cloud_profile_cache_ttl"`
+	Polling              *pollingModel `tfsdk:"polling"`
+
+	VersionExpirationWarningDays types.Int64 `tfsdk:"version_expiration_warning_days"`
+}
+
+// cleuraProviderData is passed to data sources and resources in their Configure methods.
+type cleuraProviderData struct {
+	client   *apiClient
+	defaults providerDefaults
+}
+
+// providerDefaults holds provider level values for attributes repeated across resources and data sources.
+type providerDefaults struct {
+	project        string
+	region         string
+	gardenerDomain string
+	polling        pollingSchedule
+	// deletionProtection of clusters not setting deletion_protection.
+	deletionProtection bool
+	// versionExpirationWarning is how long before their expiration date planned versions are warned about.
+	versionExpirationWarning time.Duration
 }
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -71,7 +111,7 @@ func (p *cleuraProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				Sensitive:   true,
 			},
 			"config_file": schema.StringAttribute{
-				Description: "Configuration file path generated by cleura cli with defined active_profile, containing Username, Token and Api url",
+				Description: "Configuration file path generated by cleura cli with defined active_profile, containing Username, Token and Api url. See `profile` to use a profile other than active_profile.",
 				Optional:    true,
 				Validators: []validator.String{
 					stringvalidator.ConflictsWith(path.Expressions{
@@ -81,6 +121,87 @@ func (p *cleuraProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 					}...),
 				},
 			},
+			"profile": schema.StringAttribute{
+				Description: "Name of the profile in config_file to read credentials from. Takes CLEURA_PROFILE environment variable if not set, defaults to the active_profile of the configuration file.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("config_file")),
+				},
+			},
+			"default_project": schema.StringAttribute{
+				Description: "Id of the project used by resources and data sources that do not set `project`.",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"default_region": schema.StringAttribute{
+				Description: "Region used by resources and data sources that do not set `region`.",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"default_gardener_domain": schema.StringAttribute{
+				Description: "Gardener domain used by resources and data sources that do not set `gardener_domain`. Defaults to 'public'",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"default_deletion_protection": schema.BoolAttribute{
+				Description: "Deletion protection of clusters that do not set `deletion_protection`. Defaults to false.",
+				Optional:    true,
+			},
+			"cloud_profile_cache_ttl": schema.StringAttribute{
+				Description: "How long cloud profiles fetched from the API are reused by resources and data sources, e.g. '10m'. Set to '0s' to disable caching. Defaults to '5m'.",
+				Optional:    true,
+			},
+			"version_expiration_warning_days": schema.Int64Attribute{
+				Description: "Number of days before their expiration date that the Kubernetes and machine image versions of a cluster are warned about during plan. Set to 0 to only warn about deprecated versions. Defaults to 30.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"polling": schema.SingleNestedAttribute{
+				Description: "Default polling configuration of resources waiting for a cluster operation to finish.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"initial_delay": schema.StringAttribute{
+						Description: pollingAttributeDescriptions["initial_delay"],
+						Optional:    true,
+					},
+					"interval": schema.StringAttribute{
+						Description: pollingAttributeDescriptions["interval"],
+						Optional:    true,
+					},
+					"max_interval": schema.StringAttribute{
+						Description: pollingAttributeDescriptions["max_interval"],
+						Optional:    true,
+					},
+				},
+			},
+			"retry": schema.SingleNestedAttribute{
+				Description: "Retry policy for Cleura API requests failing with a transient error.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						Description: "Maximum number of attempts for each API request, including the first one. Set to 1 to disable retries. Defaults to 5.",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.AtLeast(1)},
+					},
+					"base_delay": schema.StringAttribute{
+						Description: "Delay before the first retry, doubled for each following retry. Retry-After sent by the API takes precedence. Defaults to '2s'.",
+						Optional:    true,
+					},
+					"max_delay": schema.StringAttribute{
+						Description: "Maximum delay between two attempts. Defaults to '30s'.",
+						Optional:    true,
+					},
+					"retryable_status_codes": schema.ListAttribute{
+						Description: "HTTP status codes that are retried. Defaults to 409, 429 and all 5xx status codes.",
+						Optional:    true,
+						ElementType: types.Int64Type,
+						Validators: []validator.List{
+							listvalidator.ValueInt64sAre(int64validator.Between(100, 599)),
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -133,19 +254,117 @@ func (p *cleuraProvider) Configure(ctx context.Context, req provider.ConfigureRe
 				"Either target apply the source of the value first, set the value statically in the configuration.",
 		)
 	}
+	if config.Profile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown Configuration File Profile",
+			"The provider cannot create the Cleura API client as there is an unknown configuration value for the configuration file profile. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the CLEURA_PROFILE environment variable.",
+		)
+	}
+	if config.DefaultProject.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_project"),
+			"Unknown Default Project",
+			"The provider cannot be configured as there is an unknown configuration value for the default project. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or set project on each resource and data source.",
+		)
+	}
+	if config.DefaultRegion.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_region"),
+			"Unknown Default Region",
+			"The provider cannot be configured as there is an unknown configuration value for the default region. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or set region on each resource and data source.",
+		)
+	}
+	if config.DefaultGardenerDomain.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_gardener_domain"),
+			"Unknown Default Gardener Domain",
+			"The provider cannot be configured as there is an unknown configuration value for the default gardener domain. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or set gardener_domain on each resource and data source.",
+		)
+	}
+	if config.DefaultDeletionProtection.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_deletion_protection"),
+			"Unknown Default Deletion Protection",
+			"The provider cannot be configured as there is an unknown configuration value for the default deletion protection. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or set deletion_protection on each cluster.",
+		)
+	}
+	if config.CloudProfileCacheTTL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cloud_profile_cache_ttl"),
+			"Unknown Cloud Profile Cache TTL",
+			"The provider cannot be configured as there is an unknown configuration value for the cloud profile cache TTL. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+	if config.VersionExpirationWarningDays.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("version_expiration_warning_days"),
+			"Unknown Version Expiration Warning Days",
+			"The provider cannot be configured as there is an unknown configuration value for the version expiration warning days. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	retry := newRetryPolicy(ctx, config.Retry, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	polling := newPollingSchedule(config.Polling, defaultPollingSchedule(), path.Root("polling"), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	cloudProfileCacheTTL := defaultCloudProfileCacheTTL
+	if !config.CloudProfileCacheTTL.IsNull() {
+		ttl, err := time.ParseDuration(config.CloudProfileCacheTTL.ValueString())
+		if err != nil || ttl < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("cloud_profile_cache_ttl"),
+				"Invalid Cloud Profile Cache TTL",
+				fmt.Sprintf("Expected a non-negative duration such as '10m', got: %q.", config.CloudProfileCacheTTL.ValueString()),
+			)
+			return
+		}
+		cloudProfileCacheTTL = ttl
+	}
+
 	var username, token, host string
 	var readConfigError error
 
+	// Profile from the Terraform configuration takes precedence over CLEURA_PROFILE,
+	// active_profile of the configuration file is used if neither is set.
+	profile := os.Getenv("CLEURA_PROFILE")
+	if !config.Profile.IsNull() {
+		profile = config.Profile.ValueString()
+	}
+
 	// Try get auth credentials from configuration file,
 	// switch to environment variables if failed.
 	if !config.ConfigFile.IsNull() {
 		tflog.Info(ctx, "Given path string: "+config.ConfigFile.ValueString())
-		username, token, host, readConfigError = setAuthCredsFromConfig(ctx, config.ConfigFile.ValueString())
+		username, token, host, readConfigError = setAuthCredsFromConfig(ctx, config.ConfigFile.ValueString(), profile)
+		var notFoundErr *profileNotFoundError
+		if errors.As(readConfigError, &notFoundErr) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("profile"),
+				"Missing Configuration File Profile",
+				fmt.Sprintf("The provider cannot create the Cleura API client as profile %q is not defined in configuration file %s. "+
+					"Set the profile value in the configuration or the CLEURA_PROFILE environment variable to one of the available profiles: %s.",
+					notFoundErr.profile, config.ConfigFile.ValueString(), strings.Join(notFoundErr.available, ", ")),
+			)
+			return
+		}
 		if readConfigError != nil {
 			tflog.Warn(ctx, "can not process given configuration file or no configuration file provided, set environmental variables instead")
 		}
@@ -233,10 +452,37 @@ func (p *cleuraProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	// Make the Cleura client available during DataSource and Resource
+	defaults := providerDefaults{
+		project:        config.DefaultProject.ValueString(),
+		region:         config.DefaultRegion.ValueString(),
+		gardenerDomain: config.DefaultGardenerDomain.ValueString(),
+		polling:        polling,
+
+		deletionProtection:       config.DefaultDeletionProtection.ValueBool(),
+		versionExpirationWarning: defaultVersionExpirationWarning,
+	}
+	if defaults.gardenerDomain == "" {
+		defaults.gardenerDomain = "public"
+	}
+	if !config.VersionExpirationWarningDays.IsNull() {
+		defaults.versionExpirationWarning = time.Duration(config.VersionExpirationWarningDays.ValueInt64()) * 24 * time.Hour
+	}
+
+	// Make the Cleura client and provider defaults available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	providerData := &cleuraProviderData{
+		client: &apiClient{
+			client: client,
+			retry:  retry,
+		},
+		defaults: defaults,
+	}
+	if cloudProfileCacheTTL > 0 {
+		providerData.client.profiles = newCloudProfileCache(cloudProfileCacheTTL)
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
 	tflog.Info(ctx, "Configured Cleura client", map[string]any{"success": true})
 }
 
@@ -253,21 +499,49 @@ func (p *cleuraProvider) Resources(_ context.Context) []func() resource.Resource
 	return []func() resource.Resource{
 		NewShootClusterResource,
 		NewShootClusterKubeconfigResource,
+		NewShootWorkerGroupResource,
+	}
+}
+
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *cleuraProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewShootClusterKubeconfigEphemeralResource,
 	}
 }
 
-// Get auth parameters from configuration file.
-func setAuthCredsFromConfig(ctx context.Context, path string) (string, string, string, error) {
+// profileNotFoundError is returned when the requested profile is not defined in the configuration file.
+type profileNotFoundError struct {
+	profile   string
+	available []string
+}
+
+func (e *profileNotFoundError) Error() string {
+	return fmt.Sprintf("profile `%s` is not defined in configuration file, available profiles: %s", e.profile, strings.Join(e.available, ", "))
+}
+
+// Get auth parameters from configuration file. Active profile is used if profile is empty.
+func setAuthCredsFromConfig(ctx context.Context, path string, profile string) (string, string, string, error) {
 	fileConfig, err := cf.InitConfiguration(path)
 	if err != nil {
 		return "", "", "", err
 	}
-	tflog.Info(ctx, "Active profile found: "+fileConfig.GetActiveProfile())
-	profileData, err := fileConfig.GetProfileMap(fileConfig.GetActiveProfile())
+	if profile == "" {
+		profile = fileConfig.GetActiveProfile()
+		tflog.Info(ctx, "Active profile found: "+profile)
+	} else {
+		available := fileConfig.ProfilesSlice()
+		slices.Sort(available)
+		if !slices.Contains(available, profile) {
+			return "", "", "", &profileNotFoundError{profile: profile, available: available}
+		}
+		tflog.Info(ctx, "Using profile: "+profile)
+	}
+	profileData, err := fileConfig.GetProfileMap(profile)
 	if err != nil {
 		return "", "", "", err
 	}
-	tflog.Info(ctx, "Got active_profile data")
+	tflog.Info(ctx, "Got profile data")
 	token, ok := profileData["token"].(string)
 	if !ok {
 		return "", "", "", fmt.Errorf("can't convert token value from configuration file to string")
@@ -282,3 +556,58 @@ func setAuthCredsFromConfig(ctx context.Context, path string) (string, string, s
 	}
 	return username, token, host, nil
 }
+
+// withDefault returns value if it is configured, otherwise fallback. Null is returned if neither is set.
+func withDefault(value types.String, fallback string) types.String {
+	if !value.IsNull() || fallback == "" {
+		return value
+	}
+	return types.StringValue(fallback)
+}
+
+// addMissingDefaultError adds an error for the attribute name, which is set neither in the configuration of
+// the block, e.g. "data source", nor as default_<name> in the provider configuration.
+func addMissingDefaultError(diags *diag.Diagnostics, name string, block string) {
+	diags.AddAttributeError(
+		path.Root(name),
+		"Missing Attribute Configuration",
+		fmt.Sprintf("Either set `%s` in the %s configuration or `default_%s` in the provider configuration.", name, block, name),
+	)
+}
+
+// planLocation resolves project, region and gardener_domain of a resource from its configuration,
+// falling back to the provider defaults, so that the plan shows the values in use. As the resolved
+// values identify the cluster, changing any of them for an existing resource requires replacement.
+func (d providerDefaults) planLocation(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, project, region, gardenerDomain *types.String) {
+	for _, attr := range []struct {
+		name     string
+		fallback string
+		value    *types.String
+	}{
+		{name: "project", fallback: d.project, value: project},
+		{name: "region", fallback: d.region, value: region},
+		{name: "gardener_domain", fallback: d.gardenerDomain, value: gardenerDomain},
+	} {
+		attrPath := path.Root(attr.name)
+		var configValue types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, attrPath, &configValue)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		*attr.value = withDefault(configValue, attr.fallback)
+		if attr.value.IsNull() {
+			addMissingDefaultError(&resp.Diagnostics, attr.name, "resource")
+			continue
+		}
+
+		if req.State.Raw.IsNull() {
+			continue
+		}
+		var stateValue types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, attrPath, &stateValue)...)
+		if !attr.value.Equal(stateValue) {
+			resp.RequiresReplace = append(resp.RequiresReplace, attrPath)
+		}
+	}
+}
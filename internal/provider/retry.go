@@ -0,0 +1,344 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// retryPolicy defines how Cleura API requests failing with a transient error are retried.
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	// statusCodes to retry on, nil retries 409, 429 and all 5xx status codes.
+	statusCodes []int
+}
+
+type retryModel struct {
+	MaxAttempts          types.Int64  `tfsdk:"max_attempts"`
+	BaseDelay            types.String `tfsdk:"base_delay"`
+	MaxDelay             types.String `tfsdk:"max_delay"`
+	RetryableStatusCodes types.List   `tfsdk:"retryable_status_codes"`
+}
+
+func defaultRetryPolicy() retryPolicy {
+	return retryPolicy{
+		maxAttempts: 5,
+		baseDelay:   2 * time.Second,
+		maxDelay:    30 * time.Second,
+	}
+}
+
+func (p retryPolicy) isRetryable(statusCode int) bool {
+	if p.statusCodes != nil {
+		return slices.Contains(p.statusCodes, statusCode)
+	}
+	return statusCode == http.StatusConflict || statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// delay returns how long to wait before the given retry attempt (starting from 1), doubling the
+// base delay on each attempt up to the maximum delay. Retry-After sent by the API takes precedence.
+func (p retryPolicy) delay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	delay := p.baseDelay
+	for i := 1; i < attempt && delay < p.maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.maxDelay)
+}
+
+// apiClient wraps the Cleura API client and retries failed requests according to the retry policy.
+type apiClient struct {
+	client *cleura.Client
+	retry  retryPolicy
+	// profiles caches GetCloudProfile responses, nil disables caching.
+	profiles *cloudProfileCache
+}
+
+func (c *apiClient) GetShootCluster(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string) (*cleura.ShootClusterResponse, error) {
+	return callWithRetry(ctx, c, "GetShootCluster", func(client *cleura.Client) (*cleura.ShootClusterResponse, error) {
+		return client.GetShootCluster(gardenDomain, clusterName, clusterRegion, clusterProject)
+	})
+}
+
+func (c *apiClient) CreateShootCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, request shootClusterRequest) (*cleura.ShootClusterCreateResponse, error) {
+	call := func(client *cleura.Client) (*cleura.ShootClusterCreateResponse, error) {
+		return createShootCluster(client, gardenDomain, clusterRegion, clusterProject, request)
+	}
+	created := func(ctx context.Context, apiErr *cleura.RequestAPIError) (*cleura.ShootClusterCreateResponse, bool, error) {
+		if apiErr.StatusCode != http.StatusConflict {
+			return nil, false, nil
+		}
+		shoot, err := c.GetShootCluster(ctx, gardenDomain, request.Shoot.Name, clusterRegion, clusterProject)
+		if isNotFound(err) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return &cleura.ShootClusterCreateResponse{Shoot: cleura.ShootClusterCreateConfigResponse{Name: shoot.Metadata.Name, UID: shoot.Metadata.UID}}, true, nil
+	}
+	return retryCall(ctx, c, "CreateShootCluster", call, created)
+}
+
+func (c *apiClient) UpdateShootCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string, request shootClusterRequest) (*cleura.ShootClusterResponse, error) {
+	return callWithRetry(ctx, c, "UpdateShootCluster", func(client *cleura.Client) (*cleura.ShootClusterResponse, error) {
+		return updateShootCluster(client, gardenDomain, clusterRegion, clusterProject, clusterName, request)
+	})
+}
+
+func (c *apiClient) DeleteShootCluster(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string) (string, error) {
+	call := func(client *cleura.Client) (string, error) {
+		return client.DeleteShootCluster(gardenDomain, clusterName, clusterRegion, clusterProject)
+	}
+	deleted := func(_ context.Context, apiErr *cleura.RequestAPIError) (string, bool, error) {
+		return "", apiErr.StatusCode == http.StatusNotFound, nil
+	}
+	return retryCall(ctx, c, "DeleteShootCluster", call, deleted)
+}
+
+func (c *apiClient) AddWorkerGroup(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerGroupRequest cleura.WorkerGroupRequest) (*cleura.ShootClusterResponse, error) {
+	call := func(client *cleura.Client) (*cleura.ShootClusterResponse, error) {
+		return client.AddWorkerGroup(gardenDomain, clusterName, clusterRegion, clusterProject, workerGroupRequest)
+	}
+	created := func(ctx context.Context, apiErr *cleura.RequestAPIError) (*cleura.ShootClusterResponse, bool, error) {
+		if apiErr.StatusCode != http.StatusConflict {
+			return nil, false, nil
+		}
+		shoot, err := c.GetShootCluster(ctx, gardenDomain, clusterName, clusterRegion, clusterProject)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, worker := range shoot.Spec.Provider.Workers {
+			if worker.Name == workerGroupRequest.Worker.Name {
+				return shoot, true, nil
+			}
+		}
+		return nil, false, nil
+	}
+	return retryCall(ctx, c, "AddWorkerGroup", call, created)
+}
+
+func (c *apiClient) UpdateWorkerGroup(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerName string, workerGroupRequest cleura.WorkerGroupRequest) (*cleura.ShootClusterResponse, error) {
+	return callWithRetry(ctx, c, "UpdateWorkerGroup", func(client *cleura.Client) (*cleura.ShootClusterResponse, error) {
+		return client.UpdateWorkerGroup(gardenDomain, clusterName, clusterRegion, clusterProject, workerName, workerGroupRequest)
+	})
+}
+
+func (c *apiClient) DeleteWorkerGroup(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string, workerName string) (*cleura.ShootClusterResponse, error) {
+	return callWithRetry(ctx, c, "DeleteWorkerGroup", func(client *cleura.Client) (*cleura.ShootClusterResponse, error) {
+		return client.DeleteWorkerGroup(gardenDomain, clusterName, clusterRegion, clusterProject, workerName)
+	})
+}
+
+func (c *apiClient) GetCloudProfile(ctx context.Context, gardenDomain string) (*cleura.CloudProfile, error) {
+	fetch := func() (*cleura.CloudProfile, error) {
+		return callWithRetry(ctx, c, "GetCloudProfile", func(client *cleura.Client) (*cleura.CloudProfile, error) {
+			return client.GetCloudProfile(gardenDomain)
+		})
+	}
+	if c.profiles == nil {
+		return fetch()
+	}
+	return c.profiles.get(ctx, gardenDomain, fetch)
+}
+
+func (c *apiClient) GenerateKubeConfig(ctx context.Context, gardenDomain, clusterRegion string, clusterProject string, clusterName string, durationSeconds int64) ([]byte, error) {
+	return callWithRetry(ctx, c, "GenerateKubeConfig", func(client *cleura.Client) ([]byte, error) {
+		return client.GenerateKubeConfig(gardenDomain, clusterRegion, clusterProject, clusterName, durationSeconds)
+	})
+}
+
+func (c *apiClient) HibernateCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string) error {
+	_, err := callWithRetry(ctx, c, "HibernateCluster", func(client *cleura.Client) (struct{}, error) {
+		return struct{}{}, client.HibernateCluster(gardenDomain, clusterRegion, clusterProject, clusterName)
+	})
+	return err
+}
+
+func (c *apiClient) WakeUpCluster(ctx context.Context, gardenDomain string, clusterRegion string, clusterProject string, clusterName string) error {
+	_, err := callWithRetry(ctx, c, "WakeUpCluster", func(client *cleura.Client) (struct{}, error) {
+		return struct{}{}, client.WakeUpCluster(gardenDomain, clusterRegion, clusterProject, clusterName)
+	})
+	return err
+}
+
+// isNotFound reports whether the Cleura API responded with 404, e.g. for a cluster or worker group deleted
+// outside of Terraform.
+func isNotFound(err error) bool {
+	var apiErr *cleura.RequestAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// callWithRetry runs call until it succeeds, fails with an error that is not retryable or the
+// retry policy is exhausted. The error of the last attempt is returned.
+func callWithRetry[T any](ctx context.Context, c *apiClient, operation string, call func(*cleura.Client) (T, error)) (T, error) {
+	return retryCall(ctx, c, operation, call, nil)
+}
+
+// retryCall is callWithRetry, optionally checking whether an earlier attempt was applied. An attempt failing
+// with a 5xx status code may still have been applied by the API, e.g. created or deleted the object, so the
+// retry fails with 409 or 404. applied is then passed the error of the retry, and reports whether the
+// earlier attempt was applied and the result to return instead of the error. It is only called after an
+// attempt failing with a 5xx status code, so objects that already existed before the first attempt are never
+// taken over, and is nil for requests that can be retried safely.
+func retryCall[T any](ctx context.Context, c *apiClient, operation string, call func(*cleura.Client) (T, error), applied func(context.Context, *cleura.RequestAPIError) (T, bool, error)) (T, error) {
+	// mayHaveApplied is set once an attempt failed in a way that does not tell whether it was applied.
+	mayHaveApplied := false
+	for attempt := 1; ; attempt++ {
+		// Each attempt gets its own copy of the client to record Retry-After of its own response.
+		recorder := &retryAfterRecorder{}
+		client := withTransport(c.client, func(transport http.RoundTripper) http.RoundTripper {
+			recorder.transport = transport
+			return recorder
+		})
+
+		result, err := call(client)
+		if err == nil {
+			return result, nil
+		}
+		var apiErr *cleura.RequestAPIError
+		if !errors.As(err, &apiErr) {
+			return result, err
+		}
+		if mayHaveApplied && applied != nil {
+			appliedResult, ok, lookupErr := applied(ctx, apiErr)
+			if lookupErr == nil && ok {
+				tflog.Info(ctx, "Cleura API request was applied by an earlier attempt", map[string]any{
+					"operation":   operation,
+					"attempt":     attempt,
+					"status_code": apiErr.StatusCode,
+				})
+				return appliedResult, nil
+			}
+		}
+		if apiErr.StatusCode >= 500 {
+			mayHaveApplied = true
+		}
+		if attempt >= c.retry.maxAttempts || !c.retry.isRetryable(apiErr.StatusCode) {
+			return result, err
+		}
+
+		delay := c.retry.delay(attempt, recorder.retryAfter)
+		tflog.Info(ctx, "Retrying Cleura API request", map[string]any{
+			"operation":    operation,
+			"attempt":      attempt,
+			"max_attempts": c.retry.maxAttempts,
+			"status_code":  apiErr.StatusCode,
+			"retry_after":  recorder.retryAfter.String(),
+			"delay":        delay.String(),
+		})
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// withTransport returns a copy of the client sending its requests through the transport returned by wrap,
+// which is passed the transport of the client.
+func withTransport(client *cleura.Client, wrap func(http.RoundTripper) http.RoundTripper) *cleura.Client {
+	transport := client.HTTPClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	httpClient := *client.HTTPClient
+	httpClient.Transport = wrap(transport)
+	clone := *client
+	clone.HTTPClient = &httpClient
+	return &clone
+}
+
+// retryAfterRecorder is an http.RoundTripper keeping the Retry-After header of the last response.
+type retryAfterRecorder struct {
+	transport  http.RoundTripper
+	retryAfter time.Duration
+}
+
+func (r *retryAfterRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err == nil {
+		r.retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	}
+	return resp, err
+}
+
+// parseRetryAfter converts Retry-After header value given either in seconds or as an HTTP date to a duration.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// newRetryPolicy returns the retry policy configured in the provider retry attribute, using
+// defaults for everything that is not set.
+func newRetryPolicy(ctx context.Context, config *retryModel, diags *diag.Diagnostics) retryPolicy {
+	policy := defaultRetryPolicy()
+	if config == nil {
+		return policy
+	}
+
+	if !config.MaxAttempts.IsNull() {
+		policy.maxAttempts = int(config.MaxAttempts.ValueInt64())
+	}
+	if !config.BaseDelay.IsNull() {
+		delay, err := time.ParseDuration(config.BaseDelay.ValueString())
+		if err != nil || delay < 0 {
+			diags.AddAttributeError(
+				path.Root("retry").AtName("base_delay"),
+				"Invalid Retry Base Delay",
+				fmt.Sprintf("Expected a non-negative duration such as '2s', got: %q.", config.BaseDelay.ValueString()),
+			)
+		}
+		policy.baseDelay = delay
+	}
+	if !config.MaxDelay.IsNull() {
+		delay, err := time.ParseDuration(config.MaxDelay.ValueString())
+		if err != nil || delay < 0 {
+			diags.AddAttributeError(
+				path.Root("retry").AtName("max_delay"),
+				"Invalid Retry Max Delay",
+				fmt.Sprintf("Expected a non-negative duration such as '2s', got: %q.", config.MaxDelay.ValueString()),
+			)
+		}
+		policy.maxDelay = delay
+	}
+	if policy.baseDelay > policy.maxDelay {
+		diags.AddAttributeError(
+			path.Root("retry"),
+			"Invalid Attribute Configuration",
+			fmt.Sprintf("`base_delay` (%s) can not be greater than `max_delay` (%s).", policy.baseDelay, policy.maxDelay),
+		)
+	}
+	if !config.RetryableStatusCodes.IsNull() {
+		var statusCodes []int64
+		diags.Append(config.RetryableStatusCodes.ElementsAs(ctx, &statusCodes, false)...)
+		policy.statusCodes = make([]int, 0, len(statusCodes))
+		for _, code := range statusCodes {
+			policy.statusCodes = append(policy.statusCodes, int(code))
+		}
+	}
+
+	return policy
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{name: "empty", value: "", expected: 0},
+		{name: "seconds", value: "120", expected: 2 * time.Minute},
+		{name: "zero seconds", value: "0", expected: 0},
+		{name: "negative seconds", value: "-5", expected: 0},
+		{name: "HTTP date", value: "Sat, 01 Jun 2024 12:00:30 GMT", expected: 30 * time.Second},
+		{name: "past HTTP date", value: "Sat, 01 Jun 2024 11:59:00 GMT", expected: 0},
+		{name: "garbage", value: "soon", expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value, now); got != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := retryPolicy{baseDelay: 2 * time.Second, maxDelay: 30 * time.Second}
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter time.Duration
+		expected   time.Duration
+	}{
+		{name: "first retry", attempt: 1, expected: 2 * time.Second},
+		{name: "doubled", attempt: 3, expected: 8 * time.Second},
+		{name: "capped", attempt: 5, expected: 30 * time.Second},
+		{name: "capped without overflow", attempt: 100, expected: 30 * time.Second},
+		{name: "retry-after takes precedence", attempt: 1, retryAfter: 10 * time.Second, expected: 10 * time.Second},
+		{name: "retry-after is not capped", attempt: 1, retryAfter: time.Minute, expected: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.delay(tt.attempt, tt.retryAfter); got != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyIsRetryable(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCodes []int
+		statusCode  int
+		expected    bool
+	}{
+		{name: "default conflict", statusCode: http.StatusConflict, expected: true},
+		{name: "default too many requests", statusCode: http.StatusTooManyRequests, expected: true},
+		{name: "default server error", statusCode: http.StatusBadGateway, expected: true},
+		{name: "default not found", statusCode: http.StatusNotFound, expected: false},
+		{name: "default bad request", statusCode: http.StatusBadRequest, expected: false},
+		{name: "configured", statusCodes: []int{503}, statusCode: http.StatusServiceUnavailable, expected: true},
+		{name: "not configured", statusCodes: []int{503}, statusCode: http.StatusConflict, expected: false},
+		{name: "none configured", statusCodes: []int{}, statusCode: http.StatusServiceUnavailable, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := retryPolicy{statusCodes: tt.statusCodes}
+			if got := policy.isRetryable(tt.statusCode); got != tt.expected {
+				t.Fatalf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewRetryPolicyDelays(t *testing.T) {
+	tests := []struct {
+		name      string
+		baseDelay string
+		maxDelay  string
+		expected  []string
+	}{
+		{name: "valid", baseDelay: "1s", maxDelay: "10s"},
+		{name: "zero", baseDelay: "0s", maxDelay: "0s"},
+		{name: "negative base delay", baseDelay: "-5s", maxDelay: "10s", expected: []string{"retry.base_delay"}},
+		{name: "negative max delay", baseDelay: "0s", maxDelay: "-5s", expected: []string{"retry.max_delay", "retry"}},
+		{name: "invalid base delay", baseDelay: "soon", maxDelay: "10s", expected: []string{"retry.base_delay"}},
+		{name: "base delay greater than max delay", baseDelay: "10s", maxDelay: "1s", expected: []string{"retry"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &retryModel{
+				MaxAttempts:          types.Int64Null(),
+				BaseDelay:            types.StringValue(tt.baseDelay),
+				MaxDelay:             types.StringValue(tt.maxDelay),
+				RetryableStatusCodes: types.ListNull(types.Int64Type),
+			}
+			var diags diag.Diagnostics
+			newRetryPolicy(context.Background(), config, &diags)
+			got := testErrorPaths(diags)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected errors for %v, got %v", tt.expected, diags)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Fatalf("expected errors for %v, got %v", tt.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryCallApplied(t *testing.T) {
+	ctx := context.Background()
+	operations := map[string]func(c *apiClient) error{
+		"CreateShootCluster": func(c *apiClient) error {
+			request := shootClusterRequest{Shoot: shootClusterRequestConfig{ShootClusterRequestConfig: cleura.ShootClusterRequestConfig{Name: "test"}}}
+			shoot, err := c.CreateShootCluster(ctx, "public", "sto2", "project-id", request)
+			if err == nil && shoot.Shoot.UID != "abc123" {
+				t.Errorf("expected the existing cluster, got %+v", shoot.Shoot)
+			}
+			return err
+		},
+		"AddWorkerGroup": func(c *apiClient) error {
+			request := cleura.WorkerGroupRequest{Worker: cleura.WorkerRequest{Name: "wr001"}}
+			shoot, err := c.AddWorkerGroup(ctx, "public", "test", "sto2", "project-id", request)
+			if err == nil && shoot.Metadata.UID != "abc123" {
+				t.Errorf("expected the existing cluster, got %+v", shoot.Metadata)
+			}
+			return err
+		},
+		"DeleteShootCluster": func(c *apiClient) error {
+			_, err := c.DeleteShootCluster(ctx, "public", "test", "sto2", "project-id")
+			return err
+		},
+	}
+
+	tests := []struct {
+		name        string
+		operation   string
+		statusCodes []int
+		expectError bool
+	}{
+		// The first attempt creates the object, but its response is lost.
+		{name: "cluster created by an earlier attempt", operation: "CreateShootCluster", statusCodes: []int{504, 409}},
+		{name: "worker group created by an earlier attempt", operation: "AddWorkerGroup", statusCodes: []int{504, 409}},
+		{name: "cluster deleted by an earlier attempt", operation: "DeleteShootCluster", statusCodes: []int{504, 404}},
+		// Conflicts not preceded by a failure that may have created the object are caused by an existing object,
+		// which must not be taken over.
+		{name: "existing cluster", operation: "CreateShootCluster", statusCodes: []int{409, 409, 409}, expectError: true},
+		{name: "existing worker group", operation: "AddWorkerGroup", statusCodes: []int{409, 409, 409}, expectError: true},
+		{name: "missing cluster", operation: "DeleteShootCluster", statusCodes: []int{404}, expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					_, _ = w.Write([]byte(`{"metadata": {"name": "test", "uid": "abc123"}, "spec": {"provider": {"workers": [{"name": "wr001"}]}}}`))
+					return
+				}
+				w.WriteHeader(tt.statusCodes[min(attempts, len(tt.statusCodes)-1)])
+				attempts++
+			}))
+			defer server.Close()
+
+			// The default retry policy, retrying 409.
+			c := &apiClient{
+				client: &cleura.Client{HostURL: server.URL, HTTPClient: server.Client()},
+				retry:  retryPolicy{maxAttempts: 3, baseDelay: time.Millisecond, maxDelay: time.Millisecond},
+			}
+			err := operations[tt.operation](c)
+			if tt.expectError != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tt.expectError, err)
+			}
+			if attempts != len(tt.statusCodes) {
+				t.Errorf("expected %d attempts, got %d", len(tt.statusCodes), attempts)
+			}
+		})
+	}
+}
@@ -3,7 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
-	"sort"
+	"strings"
 
 	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
 	version "github.com/hashicorp/go-version"
@@ -15,7 +15,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 var (
@@ -86,7 +85,8 @@ func NewShootClusterProfilesDataSource() datasource.DataSource {
 }
 
 type shootClusterProfilesDataSource struct {
-	client *cleura.Client
+	client   *apiClient
+	defaults providerDefaults
 }
 
 // Metadata returns the data source type name.
@@ -99,8 +99,9 @@ func (d *shootClusterProfilesDataSource) Schema(_ context.Context, _ datasource.
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"gardener_domain": schema.StringAttribute{
-				Computed: true,
-				Optional: true,
+				Computed:    true,
+				Optional:    true,
+				Description: "Gardener domain. Defaults to the provider default_gardener_domain.",
 			},
 			"kubernetes_latest": schema.StringAttribute{
 				Computed: true,
@@ -285,11 +286,9 @@ func (d *shootClusterProfilesDataSource) Read(ctx context.Context, req datasourc
 		return
 	}
 
-	if state.GardenerDomain.IsNull() {
-		state.GardenerDomain = types.StringValue("public")
-	}
+	state.GardenerDomain = withDefault(state.GardenerDomain, d.defaults.gardenerDomain)
 
-	profile, err := d.client.GetCloudProfile(state.GardenerDomain.ValueString())
+	profile, err := d.client.GetCloudProfile(ctx, state.GardenerDomain.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to get profile data",
@@ -297,8 +296,12 @@ func (d *shootClusterProfilesDataSource) Read(ctx context.Context, req datasourc
 		)
 		return
 	}
-	state.KubernetesLatest = getLatestK8sVersion(profile)
-	state.MachineImageLatest = getLatestGardenlinuxVersion(profile)
+	if latest, err := getLatestVersion(profile.Spec.Kubernetes.Versions, false); err == nil {
+		state.KubernetesLatest = types.StringValue(latest)
+	}
+	if latest, err := getLatestVersion(machineImageVersions(profile, "gardenlinux"), false); err == nil {
+		state.MachineImageLatest = types.StringValue(latest)
+	}
 	fProfile := filterProfile(profile, state.Filters)
 
 	for _, version := range fProfile.Spec.Kubernetes.Versions {
@@ -369,17 +372,18 @@ func (d *shootClusterProfilesDataSource) Configure(_ context.Context, req dataso
 		return
 	}
 
-	client, ok := req.ProviderData.(*cleura.Client)
+	providerData, ok := req.ProviderData.(*cleuraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *cleura.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *cleuraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.client
+	d.defaults = providerData.defaults
 }
 
 func filterProfile(p *cleura.CloudProfile, f *shootClusterProfileFilters) *cleura.CloudProfile {
@@ -437,39 +441,107 @@ func filterProfile(p *cleura.CloudProfile, f *shootClusterProfileFilters) *cleur
 	return p
 }
 
-func getLatestK8sVersion(p *cleura.CloudProfile) basetypes.StringValue {
-	var listK8sVersions []string
-	for _, kVer := range p.Spec.Kubernetes.Versions {
-		if kVer.Classification == "supported" {
-			listK8sVersions = append(listK8sVersions, kVer.Version)
+// selectableVersion reports whether versions of the classification are selected when a version is not set
+// explicitly. Supported versions are always selected, preview versions only if allowed.
+func selectableVersion(classification string, allowPreview bool) bool {
+	return classification == "supported" || (allowPreview && classification == "preview")
+}
+
+// selectableClassifications describes the classifications selectableVersion selects, for error messages.
+func selectableClassifications(allowPreview bool) string {
+	if allowPreview {
+		return "supported or preview"
+	}
+	return "supported"
+}
+
+// getLatestVersion returns the highest selectable version.
+func getLatestVersion(versions []cleura.CPVersion, allowPreview bool) (string, error) {
+	var latest *version.Version
+	for _, v := range versions {
+		if !selectableVersion(v.Classification, allowPreview) {
+			continue
+		}
+		parsed, err := version.NewVersion(v.Version)
+		if err != nil {
+			continue
+		}
+		if latest == nil || parsed.GreaterThan(latest) {
+			latest = parsed
 		}
 	}
-	vers := make([]*version.Version, len(listK8sVersions))
-	for i, raw := range listK8sVersions {
-		v, _ := version.NewVersion(raw)
-		vers[i] = v
+	if latest == nil {
+		return "", fmt.Errorf("the cloud profile offers no %s version", selectableClassifications(allowPreview))
 	}
-	sort.Sort(sort.Reverse(version.Collection(vers)))
+	return latest.Original(), nil
+}
 
-	return types.StringValue(vers[0].Original())
+// versionClassification returns the classification of the version in the cloud profile, or null if the version
+// is not offered.
+func versionClassification(versions []cleura.CPVersion, v types.String) types.String {
+	for _, cpVersion := range versions {
+		if cpVersion.Version == v.ValueString() {
+			return types.StringValue(cpVersion.Classification)
+		}
+	}
+	return types.StringNull()
 }
 
-func getLatestGardenlinuxVersion(p *cleura.CloudProfile) basetypes.StringValue {
-	var listMachineVersions []string
-	for _, mVer := range p.Spec.MachineImages {
-		if mVer.Name == "gardenlinux" {
-			for _, v := range mVer.Versions {
-				if v.Classification == "supported" {
-					listMachineVersions = append(listMachineVersions, v.Version)
-				}
+// regionZones returns the names of all availability zones of the region in the profile.
+func regionZones(p *cleura.CloudProfile, regionName string) []string {
+	var zones []string
+	for _, region := range p.Spec.Regions {
+		if region.Name == regionName {
+			for _, zone := range region.Zones {
+				zones = append(zones, zone.Name)
 			}
 		}
 	}
-	vers := make([]*version.Version, len(listMachineVersions))
-	for i, raw := range listMachineVersions {
-		v, _ := version.NewVersion(raw)
-		vers[i] = v
+	return zones
+}
+
+// resolveVersionConstraint returns the highest selectable version matching the constraint. The current version
+// is kept if it matches the constraint and is not older, as versions can not be downgraded.
+func resolveVersionConstraint(versions []cleura.CPVersion, constraint string, current types.String, allowPreview bool) (string, error) {
+	constraints, err := version.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+	}
+
+	var best *version.Version
+	var selectable []string
+	for _, v := range versions {
+		if !selectableVersion(v.Classification, allowPreview) {
+			continue
+		}
+		selectable = append(selectable, v.Version)
+		parsed, err := version.NewVersion(v.Version)
+		if err != nil {
+			continue
+		}
+		if constraints.Check(parsed) && (best == nil || parsed.GreaterThan(best)) {
+			best = parsed
+		}
+	}
+	if best == nil {
+		return "", fmt.Errorf("no %[1]s version matches the constraint %[2]q. The %[1]s versions are: %[3]s.", selectableClassifications(allowPreview), constraint, strings.Join(selectable, ", "))
+	}
+
+	if !current.IsNull() && !current.IsUnknown() {
+		currentVersion, err := version.NewVersion(current.ValueString())
+		if err == nil && constraints.Check(currentVersion) && !currentVersion.LessThan(best) {
+			return current.ValueString(), nil
+		}
+	}
+	return best.Original(), nil
+}
+
+// machineImageVersions returns the versions of the machine image in the profile.
+func machineImageVersions(p *cleura.CloudProfile, imageName string) []cleura.CPVersion {
+	for _, image := range p.Spec.MachineImages {
+		if image.Name == imageName {
+			return image.Versions
+		}
 	}
-	sort.Sort(sort.Reverse(version.Collection(vers)))
-	return types.StringValue(vers[0].Original())
+	return nil
 }
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testVersions are the versions of a cloud profile, unsorted as returned by the API.
+var testVersions = []cleura.CPVersion{
+	{Version: "1.30.2", Classification: "supported"},
+	{Version: "1.31.1", Classification: "preview"},
+	{Version: "1.30.4", Classification: "supported"},
+	{Version: "1.29.8", Classification: "deprecated"},
+	{Version: "1.29.4", Classification: "supported"},
+}
+
+func TestResolveVersionConstraint(t *testing.T) {
+	tests := []struct {
+		name         string
+		constraint   string
+		current      types.String
+		allowPreview bool
+		expected     string
+		wantErr      string
+	}{
+		{name: "highest match", constraint: "~> 1.30.0", current: types.StringNull(), expected: "1.30.4"},
+		{name: "unknown current version", constraint: "~> 1.30.0", current: types.StringUnknown(), expected: "1.30.4"},
+		{name: "current version upgraded", constraint: "~> 1.30.0", current: types.StringValue("1.30.2"), expected: "1.30.4"},
+		{name: "current version kept", constraint: ">= 1.29", current: types.StringValue("1.31.1"), expected: "1.31.1"},
+		{name: "current version not matching", constraint: "~> 1.29.0", current: types.StringValue("1.30.4"), expected: "1.29.4"},
+		{name: "deprecated versions skipped", constraint: ">= 1.29.5, < 1.30", current: types.StringNull(), wantErr: "no supported version matches the constraint"},
+		{name: "preview versions skipped", constraint: ">= 1.29", current: types.StringNull(), expected: "1.30.4"},
+		{name: "preview versions allowed", constraint: ">= 1.29", current: types.StringNull(), allowPreview: true, expected: "1.31.1"},
+		{name: "no match", constraint: "~> 1.32.0", current: types.StringNull(), allowPreview: true, wantErr: `no supported or preview version matches the constraint "~> 1.32.0". The supported or preview versions are: 1.30.2, 1.31.1, 1.30.4, 1.29.4.`},
+		{name: "invalid constraint", constraint: "latest", current: types.StringNull(), wantErr: `invalid version constraint "latest"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveVersionConstraint(testVersions, tt.constraint, tt.current, tt.allowPreview)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error %q, got %s", tt.wantErr, got)
+				}
+				if !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error %q, got %q", tt.wantErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetLatestVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		versions     []cleura.CPVersion
+		allowPreview bool
+		expected     string
+		wantErr      string
+	}{
+		{name: "supported", versions: testVersions, expected: "1.30.4"},
+		{name: "preview allowed", versions: testVersions, allowPreview: true, expected: "1.31.1"},
+		{name: "invalid versions skipped", versions: []cleura.CPVersion{{Version: "1.30.2", Classification: "supported"}, {Version: "latest", Classification: "supported"}}, expected: "1.30.2"},
+		{name: "only deprecated", versions: []cleura.CPVersion{{Version: "1.29.8", Classification: "deprecated"}}, allowPreview: true, wantErr: "the cloud profile offers no supported or preview version"},
+		{name: "only preview", versions: []cleura.CPVersion{{Version: "1.31.1", Classification: "preview"}}, wantErr: "the cloud profile offers no supported version"},
+		{name: "no versions", wantErr: "the cloud profile offers no supported version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLatestVersion(tt.versions, tt.allowPreview)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error %q, got %s", tt.wantErr, got)
+				}
+				if err.Error() != tt.wantErr {
+					t.Fatalf("expected error %q, got %q", tt.wantErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestVersionClassification(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  types.String
+		expected types.String
+	}{
+		{name: "supported", version: types.StringValue("1.30.4"), expected: types.StringValue("supported")},
+		{name: "preview", version: types.StringValue("1.31.1"), expected: types.StringValue("preview")},
+		{name: "deprecated", version: types.StringValue("1.29.8"), expected: types.StringValue("deprecated")},
+		{name: "not offered", version: types.StringValue("1.28.0"), expected: types.StringNull()},
+		{name: "null", version: types.StringNull(), expected: types.StringNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := versionClassification(testVersions, tt.version); !got.Equal(tt.expected) {
+				t.Fatalf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
-
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -42,6 +41,32 @@ type shootClusterAdvertisedAddressesModel struct {
 	Url  types.String `tfsdk:"url"`
 }
 
+// shootClusterConditions maps the conditions of the shoot cluster status, shared by the data source and resource.
+func shootClusterConditions(cluster *cleura.ShootClusterResponse) []shootClusterConditionsModel {
+	var conditions []shootClusterConditionsModel
+	for _, condition := range cluster.Status.Conditions {
+		conditions = append(conditions, shootClusterConditionsModel{
+			Type:    types.StringValue(condition.Type),
+			Status:  types.StringValue(condition.Status),
+			Message: types.StringValue(condition.Message),
+		})
+	}
+	return conditions
+}
+
+// shootClusterAdvertisedAddresses maps the advertised addresses of the shoot cluster status, shared by the
+// data source and resource.
+func shootClusterAdvertisedAddresses(cluster *cleura.ShootClusterResponse) []shootClusterAdvertisedAddressesModel {
+	var addresses []shootClusterAdvertisedAddressesModel
+	for _, address := range cluster.Status.AdvertisedAddresses {
+		addresses = append(addresses, shootClusterAdvertisedAddressesModel{
+			Name: types.StringValue(address.Name),
+			Url:  types.StringValue(address.Url),
+		})
+	}
+	return addresses
+}
+
 // NewCoffeesDataSource is a helper function to simplify the provider implementation.
 func NewShootClusterDataSource() datasource.DataSource {
 	return &shootClusterDataSource{}
@@ -49,7 +74,8 @@ func NewShootClusterDataSource() datasource.DataSource {
 
 // shootClusterDataSource is the data source implementation.
 type shootClusterDataSource struct {
-	client *cleura.Client
+	client   *apiClient
+	defaults providerDefaults
 }
 
 // Metadata returns the data source type name.
@@ -67,20 +93,23 @@ func (d *shootClusterDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 				Description: "Unique cluster identifier",
 			},
 			"project": schema.StringAttribute{
-				Required:    true,
-				Description: "Project where shoot cluster is created.",
+				Optional:    true,
+				Computed:    true,
+				Description: "Project where shoot cluster is created. Defaults to the provider default_project.",
 			},
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "Shoot cluster name.",
 			},
 			"gardener_domain": schema.StringAttribute{
-				Required:    true,
-				Description: "Gardener domain",
+				Optional:    true,
+				Computed:    true,
+				Description: "Gardener domain. Defaults to the provider default_gardener_domain.",
 			},
 			"region": schema.StringAttribute{
-				Required:    true,
-				Description: "Shoot cluster region.",
+				Optional:    true,
+				Computed:    true,
+				Description: "Shoot cluster region. Defaults to the provider default_region.",
 			},
 			"hibernated": schema.BoolAttribute{
 				Computed:    true,
@@ -130,7 +159,20 @@ func (d *shootClusterDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	cluster, err := d.client.GetShootCluster(state.GardenerDomain.ValueString(), state.Name.ValueString(), state.Region.ValueString(), state.Project.ValueString())
+	state.Project = withDefault(state.Project, d.defaults.project)
+	state.Region = withDefault(state.Region, d.defaults.region)
+	state.GardenerDomain = withDefault(state.GardenerDomain, d.defaults.gardenerDomain)
+	if state.Project.IsNull() {
+		addMissingDefaultError(&resp.Diagnostics, "project", "data source")
+	}
+	if state.Region.IsNull() {
+		addMissingDefaultError(&resp.Diagnostics, "region", "data source")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cluster, err := d.client.GetShootCluster(ctx, state.GardenerDomain.ValueString(), state.Name.ValueString(), state.Region.ValueString(), state.Project.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -139,22 +181,10 @@ func (d *shootClusterDataSource) Read(ctx context.Context, req datasource.ReadRe
 		)
 		return
 	}
-	//TODO: Make a function for this mapping
 	state.Hibernated = types.BoolValue(cluster.Status.Hibernated)
 	state.UID = types.StringValue(cluster.Metadata.UID)
-	for _, condition := range cluster.Status.Conditions {
-		state.Conditions = append(state.Conditions, shootClusterConditionsModel{
-			Type:    types.StringValue(condition.Type),
-			Status:  types.StringValue(condition.Status),
-			Message: types.StringValue(condition.Message),
-		})
-	}
-	for _, address := range cluster.Status.AdvertisedAddresses {
-		state.AdvertisedAddresses = append(state.AdvertisedAddresses, shootClusterAdvertisedAddressesModel{
-			Name: types.StringValue(address.Name),
-			Url:  types.StringValue(address.Url),
-		})
-	}
+	state.Conditions = shootClusterConditions(cluster)
+	state.AdvertisedAddresses = shootClusterAdvertisedAddresses(cluster)
 
 	// Set state
 	diags := resp.State.Set(ctx, &state)
@@ -170,15 +200,16 @@ func (d *shootClusterDataSource) Configure(_ context.Context, req datasource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*cleura.Client)
+	providerData, ok := req.ProviderData.(*cleuraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *cleura.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *cleuraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.client
+	d.defaults = providerData.defaults
 }
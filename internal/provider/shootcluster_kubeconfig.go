@@ -2,21 +2,30 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// defaultKubeconfigTimeout is how long generating a kubeconfig, including waiting for the API server of the
+// cluster with wait_for_ready, may take by default.
+const defaultKubeconfigTimeout = 30 * time.Minute
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
 	_ resource.Resource                   = &shootClusterKubeconfigResource{}
@@ -32,7 +41,8 @@ func NewShootClusterKubeconfigResource() resource.Resource {
 
 // shootClusterKubeconfigResource is the resource implementation.
 type shootClusterKubeconfigResource struct {
-	client *cleura.Client
+	client   *apiClient
+	defaults providerDefaults
 }
 
 // Configure adds the provider configured client to the resource.
@@ -41,17 +51,18 @@ func (r *shootClusterKubeconfigResource) Configure(_ context.Context, req resour
 		return
 	}
 
-	client, ok := req.ProviderData.(*cleura.Client)
+	providerData, ok := req.ProviderData.(*cleuraProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *cleura.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *cleuraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
-	r.client = client
+	r.client = providerData.client
+	r.defaults = providerData.defaults
 }
 
 func (r *shootClusterKubeconfigResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -81,27 +92,19 @@ func (r *shootClusterKubeconfigResource) Schema(ctx context.Context, _ resource.
 				Description: "Name of the shoot cluster",
 			},
 			"gardener_domain": schema.StringAttribute{
-				Optional: true,
-				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-				Description: "Gardener domain. Defaults to 'public'",
-				Default:     stringdefault.StaticString("public"),
+				Optional:    true,
+				Computed:    true,
+				Description: "Gardener domain. Defaults to the provider default_gardener_domain, which defaults to 'public'. Requires replace if modified.",
 			},
 			"project": schema.StringAttribute{
-				Required: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-				Description: "Id of the project where cluster will be created.",
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the project where cluster will be created. Defaults to the provider default_project. Requires replace if modified.",
 			},
 			"region": schema.StringAttribute{
-				Required: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-				Description: "One of available regions for the cluster. Depends on the enabled domains in the project",
+				Optional:    true,
+				Computed:    true,
+				Description: "One of available regions for the cluster. Depends on the enabled domains in the project. Defaults to the provider default_region. Requires replace if modified.",
 			},
 			"duration": schema.Int64Attribute{
 				Required: true,
@@ -116,9 +119,95 @@ func (r *shootClusterKubeconfigResource) Schema(ctx context.Context, _ resource.
 				Description: "Renew kubeconfig N seconds before expiry. Defaults to 300 (5 min)",
 				Default:     int64default.StaticInt64(300),
 			},
+			"wait_for_ready": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Wait until the API server of the cluster is available before generating the kubeconfig, instead of failing if it is not. Defaults to false.",
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+			}),
+			"filename": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a kubeconfig file to write the kubeconfig to, with 0600 permissions. The kubeconfig is merged into an existing file under `context_name`, keeping its other contexts, and the context is removed on destroy. A file or context removed or changed outside of Terraform is written again by the next apply.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"context_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the context, cluster and user written to `filename`. Defaults to `current_context`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("filename")),
+				},
+			},
 			"config": schema.StringAttribute{
 				Computed:    true,
+				Sensitive:   true,
 				Description: "The kubeconfig generated from the API.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"current_context": schema.StringAttribute{
+				Computed:    true,
+				Description: "The current context of the kubeconfig, which the connection attributes are read from.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"host": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The address of the Kubernetes API server.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "PEM encoded certificate authority of the Kubernetes API server.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_certificate": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "PEM encoded client certificate. Null if the kubeconfig has none.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "PEM encoded client key. Null if the kubeconfig has none.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Bearer token. Null if the kubeconfig has none.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The RFC3339 timestamp the kubeconfig expires, read from the client certificate. Computed from `generated_at` and `duration` if the kubeconfig has no client certificate.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"renew_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The RFC3339 timestamp from which the kubeconfig is renewed, `renew_before` seconds before `expires_at`.",
 			},
 			"generated_at": schema.StringAttribute{
 				Computed:    true,
@@ -143,19 +232,24 @@ func (r *shootClusterKubeconfigResource) ModifyPlan(ctx context.Context, req res
 		return
 	}
 
-	if plan.GeneratedAt.ValueString() != "" {
-		generatedAt, err := time.Parse(time.RFC3339, plan.GeneratedAt.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("failed to parse generated_at", err.Error())
-			return
-		}
+	r.defaults.planLocation(ctx, req, resp, &plan.Project, &plan.Region, &plan.GardenerDomain)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		valid_to := (plan.Duration.ValueInt64() - plan.RenewBefore.ValueInt64()) + generatedAt.Unix()
-		now := time.Now().Unix()
-		if now > valid_to {
+	expiresAt, ok := plan.expiresAt(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if ok {
+		renewAt := plan.renewAt(expiresAt)
+		plan.RenewAt = types.StringValue(renewAt.UTC().Format(time.RFC3339))
+		if time.Now().After(renewAt) {
 			plan.GeneratedAt = types.StringUnknown()
+			plan.ExpiresAt = types.StringUnknown()
+			plan.RenewAt = types.StringUnknown()
 			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("generated_at"))
-			resp.Diagnostics.AddWarning("Kubeconfig expired", "The kubeconfig duration specified has elapsed, resource will be recreated")
+			resp.Diagnostics.AddWarning("Kubeconfig expired", "The kubeconfig expires within renew_before seconds or has expired, resource will be recreated")
 		}
 	}
 
@@ -166,14 +260,75 @@ func (r *shootClusterKubeconfigResource) ModifyPlan(ctx context.Context, req res
 }
 
 type shootClusterKubeconfigResourceModel struct {
-	Name           types.String `tfsdk:"name"`
-	Region         types.String `tfsdk:"region"`
-	Project        types.String `tfsdk:"project"`
-	GardenerDomain types.String `tfsdk:"gardener_domain"`
-	Duration       types.Int64  `tfsdk:"duration"`
-	RenewBefore    types.Int64  `tfsdk:"renew_before"`
-	Config         types.String `tfsdk:"config"`
-	GeneratedAt    types.String `tfsdk:"generated_at"`
+	Name           types.String   `tfsdk:"name"`
+	Region         types.String   `tfsdk:"region"`
+	Project        types.String   `tfsdk:"project"`
+	GardenerDomain types.String   `tfsdk:"gardener_domain"`
+	Duration       types.Int64    `tfsdk:"duration"`
+	RenewBefore    types.Int64    `tfsdk:"renew_before"`
+	WaitForReady   types.Bool     `tfsdk:"wait_for_ready"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+	Filename       types.String   `tfsdk:"filename"`
+	ContextName    types.String   `tfsdk:"context_name"`
+	RenewAt        types.String   `tfsdk:"renew_at"`
+	GeneratedAt    types.String   `tfsdk:"generated_at"`
+	kubeconfigModel
+}
+
+// expiresAt returns when the kubeconfig expires, and false if it has not been generated yet. States without
+// expires_at fall back to generated_at and duration.
+func (m *shootClusterKubeconfigResourceModel) expiresAt(diags *diag.Diagnostics) (time.Time, bool) {
+	if m.ExpiresAt.ValueString() != "" {
+		expiresAt, err := time.Parse(time.RFC3339, m.ExpiresAt.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("expires_at"), "failed to parse expires_at", err.Error())
+			return time.Time{}, false
+		}
+		return expiresAt, true
+	}
+	if m.GeneratedAt.ValueString() != "" {
+		expiresAt, err := m.durationExpiry()
+		if err != nil {
+			diags.AddAttributeError(path.Root("generated_at"), "failed to parse generated_at", err.Error())
+			return time.Time{}, false
+		}
+		return expiresAt, true
+	}
+	return time.Time{}, false
+}
+
+// durationExpiry returns generated_at plus duration.
+func (m *shootClusterKubeconfigResourceModel) durationExpiry() (time.Time, error) {
+	generatedAt, err := time.Parse(time.RFC3339, m.GeneratedAt.ValueString())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return generatedAt.Add(time.Duration(m.Duration.ValueInt64()) * time.Second), nil
+}
+
+// renewAt returns the time renew_before seconds before expiresAt.
+func (m *shootClusterKubeconfigResourceModel) renewAt(expiresAt time.Time) time.Time {
+	return expiresAt.Add(-time.Duration(m.RenewBefore.ValueInt64()) * time.Second)
+}
+
+// setKubeconfig sets the kubeconfig generated at generatedAt and the attributes read from it, and renew_at
+// renew_before seconds before it expires.
+func (m *shootClusterKubeconfigResourceModel) setKubeconfig(data []byte, generatedAt time.Time) error {
+	expiresAt, err := m.kubeconfigModel.setKubeconfig(data, generatedAt, m.Duration.ValueInt64())
+	if err != nil {
+		return err
+	}
+	m.GeneratedAt = types.StringValue(generatedAt.Format(time.RFC3339))
+	m.RenewAt = types.StringValue(m.renewAt(expiresAt).UTC().Format(time.RFC3339))
+	return nil
+}
+
+// fileContextName returns the name of the context written to filename.
+func (m *shootClusterKubeconfigResourceModel) fileContextName() string {
+	if !m.ContextName.IsNull() {
+		return m.ContextName.ValueString()
+	}
+	return m.CurrentContext.ValueString()
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -184,7 +339,26 @@ func (r *shootClusterKubeconfigResource) Create(ctx context.Context, req resourc
 		return
 	}
 
-	kubeconfig, err := r.client.GenerateKubeConfig(plan.GardenerDomain.ValueString(), plan.Region.ValueString(), plan.Project.ValueString(), plan.Name.ValueString(), plan.Duration.ValueInt64())
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultKubeconfigTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	key := clusterKey{
+		gardenerDomain: plan.GardenerDomain.ValueString(),
+		region:         plan.Region.ValueString(),
+		project:        plan.Project.ValueString(),
+		name:           plan.Name.ValueString(),
+	}
+	checkClusterReady(ctx, r.client, r.defaults.polling, key, plan.WaitForReady.ValueBool(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kubeconfig, err := r.client.GenerateKubeConfig(ctx, plan.GardenerDomain.ValueString(), plan.Region.ValueString(), plan.Project.ValueString(), plan.Name.ValueString(), plan.Duration.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error generating kubeconfig",
@@ -192,8 +366,23 @@ func (r *shootClusterKubeconfigResource) Create(ctx context.Context, req resourc
 		)
 		return
 	}
-	plan.Config = types.StringValue(string(kubeconfig))
-	plan.GeneratedAt = types.StringValue(time.Now().Format(time.RFC3339))
+	if err := plan.setKubeconfig(kubeconfig, time.Now()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing kubeconfig",
+			"Could not read the kubeconfig generated for Shoot cluster "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if !plan.Filename.IsNull() {
+		if err := writeKubeconfigContext(plan.Filename.ValueString(), plan.fileContextName(), kubeconfig); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("filename"),
+				"Error writing kubeconfig",
+				"Could not write the kubeconfig of Shoot cluster "+plan.Name.ValueString()+" to "+plan.Filename.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -210,6 +399,36 @@ func (r *shootClusterKubeconfigResource) Read(ctx context.Context, req resource.
 		return
 	}
 
+	// Kubeconfigs generated before the connection and expiry attributes were added are parsed on refresh.
+	if state.CurrentContext.IsNull() || state.ExpiresAt.IsNull() {
+		generatedAt, err := time.Parse(time.RFC3339, state.GeneratedAt.ValueString())
+		if err == nil {
+			err = state.setKubeconfig([]byte(state.Config.ValueString()), generatedAt)
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("config"),
+				"Error parsing kubeconfig",
+				"Could not read the kubeconfig of Shoot cluster "+state.Name.ValueString()+": "+err.Error(),
+			)
+		}
+	}
+
+	// A kubeconfig file or context removed or changed outside of Terraform is written again by the next
+	// apply, as the filename no longer matches the configuration.
+	if !state.Filename.IsNull() {
+		written, err := kubeconfigContextWritten(state.Filename.ValueString(), state.fileContextName(), []byte(state.Config.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("filename"),
+				"Error reading kubeconfig",
+				"Could not read the kubeconfig of Shoot cluster "+state.Name.ValueString()+" from "+state.Filename.ValueString()+": "+err.Error(),
+			)
+		} else if !written {
+			state.Filename = types.StringNull()
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -218,12 +437,36 @@ func (r *shootClusterKubeconfigResource) Read(ctx context.Context, req resource.
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *shootClusterKubeconfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan shootClusterKubeconfigResourceModel
+	var plan, state shootClusterKubeconfigResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Move the context if the file or context name changed. The context is written on every update, which
+	// also restores a file or context Read found missing.
+	if !state.Filename.IsNull() && (!state.Filename.Equal(plan.Filename) || state.fileContextName() != plan.fileContextName()) {
+		if err := removeKubeconfigContext(state.Filename.ValueString(), state.fileContextName(), []byte(state.Config.ValueString())); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("filename"),
+				"Error removing kubeconfig",
+				"Could not remove the kubeconfig of Shoot cluster "+state.Name.ValueString()+" from "+state.Filename.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+	if !plan.Filename.IsNull() {
+		if err := writeKubeconfigContext(plan.Filename.ValueString(), plan.fileContextName(), []byte(plan.Config.ValueString())); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("filename"),
+				"Error writing kubeconfig",
+				"Could not write the kubeconfig of Shoot cluster "+plan.Name.ValueString()+" to "+plan.Filename.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -237,4 +480,91 @@ func (r *shootClusterKubeconfigResource) Delete(ctx context.Context, req resourc
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// A file that can not be updated must not block the destroy.
+	if !state.Filename.IsNull() {
+		if err := removeKubeconfigContext(state.Filename.ValueString(), state.fileContextName(), []byte(state.Config.ValueString())); err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("filename"),
+				"Error removing kubeconfig",
+				"Could not remove the kubeconfig of Shoot cluster "+state.Name.ValueString()+" from "+state.Filename.ValueString()+": "+err.Error(),
+			)
+		}
+	}
+}
+
+// checkClusterReady adds an error if the shoot cluster does not exist, is hibernated or its API server is not
+// available, as a kubeconfig can not be generated or used then. With wait_for_ready, it waits for the API
+// server instead.
+func checkClusterReady(ctx context.Context, client *apiClient, polling pollingSchedule, key clusterKey, waitForReady bool, diags *diag.Diagnostics) {
+	shoot, err := client.GetShootCluster(ctx, key.gardenerDomain, key.name, key.region, key.project)
+	if err != nil {
+		var re *cleura.RequestAPIError
+		if errors.As(err, &re) && re.StatusCode == 404 {
+			diags.AddAttributeError(
+				path.Root("name"),
+				"Shoot Cluster Not Found",
+				fmt.Sprintf("Shoot cluster %q does not exist in project %q, region %q. If the cluster is created in the same configuration, refer to its name, e.g. `cleura_shoot_cluster.example.name`, so it is created first.", key.name, key.project, key.region),
+			)
+			return
+		}
+		diags.AddError(
+			"Error Reading Shoot cluster",
+			"Could not read Shoot cluster name "+key.name+": "+err.Error(),
+		)
+		return
+	}
+	if shoot.Status.Hibernated {
+		addClusterHibernatedError(diags, key.name)
+		return
+	}
+	if cond, ok := apiServerCondition(shoot); ok && cond.Status == "True" {
+		return
+	}
+
+	if !waitForReady {
+		diags.AddError(
+			"Shoot Cluster Not Ready",
+			fmt.Sprintf("The API server of shoot cluster %q is not available yet (%s). Set `wait_for_ready` to wait until it is.", key.name, apiServerConditionSummary(shoot)),
+		)
+		return
+	}
+	err = clusterAPIServerWaiter(client, ctx, polling, key)
+	if errors.Is(err, errClusterHibernated) {
+		addClusterHibernatedError(diags, key.name)
+		return
+	}
+	if err != nil {
+		diags.AddError(
+			"Shoot Cluster Not Ready",
+			fmt.Sprintf("The API server of shoot cluster %q did not become available: %s", key.name, err),
+		)
+	}
+}
+
+func addClusterHibernatedError(diags *diag.Diagnostics, name string) {
+	diags.AddError(
+		"Shoot Cluster Hibernated",
+		fmt.Sprintf("Shoot cluster %q is hibernated, so a kubeconfig can not be used. Wake the cluster up, e.g. by setting `hibernation_enabled` to false on the cluster, before generating a kubeconfig.", name),
+	)
+}
+
+// apiServerConditionSummary describes the APIServerAvailable condition of the shoot cluster for diagnostics.
+func apiServerConditionSummary(shoot *cleura.ShootClusterResponse) string {
+	cond, ok := apiServerCondition(shoot)
+	if !ok {
+		return "the cluster does not report its API server condition yet"
+	}
+	if cond.Message == "" {
+		return fmt.Sprintf("%s is %s", cond.Type, cond.Status)
+	}
+	return fmt.Sprintf("%s is %s: %s", cond.Type, cond.Status, cond.Message)
+}
+
+// stringValueOrNull returns null for an empty string.
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
 }
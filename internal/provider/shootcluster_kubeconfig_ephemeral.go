@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultEphemeralKubeconfigDuration is the validity of ephemeral kubeconfigs not setting duration.
+const defaultEphemeralKubeconfigDuration = 3600
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &shootClusterKubeconfigEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &shootClusterKubeconfigEphemeralResource{}
+)
+
+// NewShootClusterKubeconfigEphemeralResource is a helper function to simplify the provider implementation.
+func NewShootClusterKubeconfigEphemeralResource() ephemeral.EphemeralResource {
+	return &shootClusterKubeconfigEphemeralResource{}
+}
+
+// shootClusterKubeconfigEphemeralResource generates a kubeconfig that is never stored in the plan or state.
+type shootClusterKubeconfigEphemeralResource struct {
+	client   *apiClient
+	defaults providerDefaults
+}
+
+type shootClusterKubeconfigEphemeralResourceModel struct {
+	Name           types.String `tfsdk:"name"`
+	Region         types.String `tfsdk:"region"`
+	Project        types.String `tfsdk:"project"`
+	GardenerDomain types.String `tfsdk:"gardener_domain"`
+	Duration       types.Int64  `tfsdk:"duration"`
+	WaitForReady   types.Bool   `tfsdk:"wait_for_ready"`
+	kubeconfigModel
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (r *shootClusterKubeconfigEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*cleuraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *cleuraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = providerData.client
+	r.defaults = providerData.defaults
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *shootClusterKubeconfigEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shoot_kubeconfig"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *shootClusterKubeconfigEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generates a short-lived kubeconfig for a shoot cluster, which is never stored in the plan or state. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the shoot cluster",
+			},
+			"gardener_domain": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Gardener domain. Defaults to the provider default_gardener_domain, which defaults to 'public'.",
+			},
+			"project": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the project of the cluster. Defaults to the provider default_project.",
+			},
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Region of the cluster. Defaults to the provider default_region.",
+			},
+			"duration": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: fmt.Sprintf("Set the duration (in seconds) for how long the kubeconfig should be valid. Defaults to %d (1 hour).", defaultEphemeralKubeconfigDuration),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Wait up to 30 minutes until the API server of the cluster is available before generating the kubeconfig, instead of failing if it is not. Defaults to false.",
+			},
+			"config": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The kubeconfig generated from the API.",
+			},
+			"current_context": schema.StringAttribute{
+				Computed:    true,
+				Description: "The current context of the kubeconfig, which the connection attributes are read from.",
+			},
+			"host": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The address of the Kubernetes API server.",
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "PEM encoded certificate authority of the Kubernetes API server.",
+			},
+			"client_certificate": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "PEM encoded client certificate. Null if the kubeconfig has none.",
+			},
+			"client_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "PEM encoded client key. Null if the kubeconfig has none.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Bearer token. Null if the kubeconfig has none.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The RFC3339 timestamp the kubeconfig expires, read from the client certificate. Computed from `duration` if the kubeconfig has no client certificate.",
+			},
+		},
+	}
+}
+
+// Open generates the kubeconfig.
+func (r *shootClusterKubeconfigEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data shootClusterKubeconfigEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Project = withDefault(data.Project, r.defaults.project)
+	data.Region = withDefault(data.Region, r.defaults.region)
+	data.GardenerDomain = withDefault(data.GardenerDomain, r.defaults.gardenerDomain)
+	if data.Project.IsNull() {
+		addMissingDefaultError(&resp.Diagnostics, "project", "ephemeral resource")
+	}
+	if data.Region.IsNull() {
+		addMissingDefaultError(&resp.Diagnostics, "region", "ephemeral resource")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Duration.IsNull() {
+		data.Duration = types.Int64Value(defaultEphemeralKubeconfigDuration)
+	}
+
+	key := clusterKey{
+		gardenerDomain: data.GardenerDomain.ValueString(),
+		region:         data.Region.ValueString(),
+		project:        data.Project.ValueString(),
+		name:           data.Name.ValueString(),
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultKubeconfigTimeout)
+	defer cancel()
+	checkClusterReady(ctx, r.client, r.defaults.polling, key, data.WaitForReady.ValueBool(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	generatedAt := time.Now()
+	kubeconfig, err := r.client.GenerateKubeConfig(ctx, key.gardenerDomain, key.region, key.project, key.name, data.Duration.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error generating kubeconfig",
+			"Could not generate kubeconfig for Shoot cluster "+data.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if _, err := data.setKubeconfig(kubeconfig, generatedAt, data.Duration.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing kubeconfig",
+			"Could not read the kubeconfig generated for Shoot cluster "+data.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
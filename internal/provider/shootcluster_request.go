@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+)
+
+// shootClusterRequest is the shoot cluster create and update request of the Cleura API. It extends
+// cleura.ShootClusterRequest with the location of hibernation schedules, which the request model of the
+// client does not support.
+type shootClusterRequest struct {
+	Shoot shootClusterRequestConfig `json:"shoot"`
+}
+
+type shootClusterRequestConfig struct {
+	cleura.ShootClusterRequestConfig
+	// Hibernation replaces the hibernation schedules of the embedded request when encoded.
+	Hibernation *hibernationSchedulesRequest `json:"hibernation,omitempty"`
+}
+
+type hibernationSchedulesRequest struct {
+	Schedules []hibernationScheduleRequest `json:"schedules,omitempty"`
+}
+
+type hibernationScheduleRequest struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Location string `json:"location,omitempty"`
+}
+
+// createShootCluster is cleura.Client.CreateShootCluster sending a shootClusterRequest.
+func createShootCluster(client *cleura.Client, gardenDomain string, clusterRegion string, clusterProject string, request shootClusterRequest) (*cleura.ShootClusterCreateResponse, error) {
+	client, clientRequest, err := withHibernationScheduleLocations(client, request)
+	if err != nil {
+		return nil, err
+	}
+	return client.CreateShootCluster(gardenDomain, clusterRegion, clusterProject, clientRequest)
+}
+
+// updateShootCluster is cleura.Client.UpdateShootCluster sending a shootClusterRequest.
+func updateShootCluster(client *cleura.Client, gardenDomain string, clusterRegion string, clusterProject string, clusterName string, request shootClusterRequest) (*cleura.ShootClusterResponse, error) {
+	client, clientRequest, err := withHibernationScheduleLocations(client, request)
+	if err != nil {
+		return nil, err
+	}
+	return client.UpdateShootCluster(gardenDomain, clusterRegion, clusterProject, clusterName, clientRequest)
+}
+
+// withHibernationScheduleLocations is a workaround for cleura.HibernationSchedule lacking the location of
+// hibernation schedules. It returns the request in the model of the client, and a copy of the client adding
+// the locations to the hibernation schedules of the encoded request, so the request is still sent by the
+// client itself.
+//
+// Remove it, together with shootClusterRequest, once the client supports the location.
+func withHibernationScheduleLocations(client *cleura.Client, request shootClusterRequest) (*cleura.Client, cleura.ShootClusterRequest, error) {
+	clientRequest := cleura.ShootClusterRequest{Shoot: request.Shoot.ShootClusterRequestConfig}
+	if request.Shoot.Hibernation == nil {
+		return client, clientRequest, nil
+	}
+	clientRequest.Shoot.Hibernation = &cleura.HibernationSchedules{}
+	for _, schedule := range request.Shoot.Hibernation.Schedules {
+		clientRequest.Shoot.Hibernation.HibernationSchedules = append(clientRequest.Shoot.Hibernation.HibernationSchedules, cleura.HibernationSchedule{
+			Start: schedule.Start,
+			End:   schedule.End,
+		})
+	}
+
+	hibernation, err := json.Marshal(request.Shoot.Hibernation)
+	if err != nil {
+		return nil, cleura.ShootClusterRequest{}, err
+	}
+	client = withTransport(client, func(transport http.RoundTripper) http.RoundTripper {
+		return &hibernationLocationTransport{transport: transport, hibernation: hibernation}
+	})
+	return client, clientRequest, nil
+}
+
+// hibernationLocationTransport is an http.RoundTripper replacing the hibernation schedules of shoot cluster
+// requests with hibernation, the encoded schedules including their location.
+type hibernationLocationTransport struct {
+	transport   http.RoundTripper
+	hibernation json.RawMessage
+}
+
+func (t *hibernationLocationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil {
+		return t.transport.RoundTrip(req)
+	}
+	requestBody, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	var body map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(requestBody, &body); err != nil {
+		return nil, err
+	}
+	if body["shoot"] == nil {
+		return nil, errors.New("shoot cluster request without shoot")
+	}
+	body["shoot"]["hibernation"] = t.hibernation
+	requestBody, err = json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	req.ContentLength = int64(len(requestBody))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(requestBody)), nil
+	}
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+)
+
+func TestUpdateShootClusterHibernationScheduleLocation(t *testing.T) {
+	var received struct {
+		Shoot struct {
+			Name        string                      `json:"name"`
+			Hibernation hibernationSchedulesRequest `json:"hibernation"`
+		} `json:"shoot"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-AUTH-TOKEN") != "token" {
+			t.Errorf("expected the token of the client, got %q", r.Header.Get("X-AUTH-TOKEN"))
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		if err := json.Unmarshal(body, &received); err != nil {
+			t.Errorf("invalid request body %s: %s", body, err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"metadata": {"name": "test"}}`))
+	}))
+	defer server.Close()
+
+	client := &cleura.Client{HostURL: server.URL, HTTPClient: server.Client(), Token: "token"}
+	request := shootClusterRequest{
+		Shoot: shootClusterRequestConfig{
+			ShootClusterRequestConfig: cleura.ShootClusterRequestConfig{Name: "test"},
+			Hibernation: &hibernationSchedulesRequest{Schedules: []hibernationScheduleRequest{
+				{Start: "00 18 * * 1,2,3,4,5", End: "00 08 * * 1,2,3,4,5", Location: "Europe/Stockholm"},
+				{Start: "00 12 * * 6", End: "00 13 * * 6"},
+			}},
+		},
+	}
+	shoot, err := updateShootCluster(client, "public", "sto2", "project-id", "test", request)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if shoot.Metadata.Name != "test" {
+		t.Errorf("expected the updated cluster, got %+v", shoot.Metadata)
+	}
+	if received.Shoot.Name != "test" {
+		t.Errorf("expected the name of the cluster to be sent, got %q", received.Shoot.Name)
+	}
+	schedules := received.Shoot.Hibernation.Schedules
+	if len(schedules) != 2 || schedules[0].Location != "Europe/Stockholm" || schedules[1].Location != "" {
+		t.Errorf("expected the hibernation schedules with their location, got %+v", schedules)
+	}
+	if client.HTTPClient.Transport != server.Client().Transport {
+		t.Error("expected the transport of the client to be kept")
+	}
+}
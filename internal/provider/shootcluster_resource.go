@@ -3,18 +3,19 @@ package provider
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"reflect"
 	"regexp"
 	"strings"
 	"time"
+	_ "time/tzdata" // hibernation schedule locations are validated against the embedded time zone database
 
 	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
-	"github.com/cenkalti/backoff/v4"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -30,7 +31,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -51,7 +54,8 @@ func NewShootClusterResource() resource.Resource {
 
 // shootClusterResource is the resource implementation.
 type shootClusterResource struct {
-	client *cleura.Client
+	client   *apiClient
+	defaults providerDefaults
 }
 
 // Configure adds the provider configured client to the resource.
@@ -60,31 +64,35 @@ func (r *shootClusterResource) Configure(_ context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*cleura.Client)
+	providerData, ok := req.ProviderData.(*cleuraProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *cleura.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *cleuraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
-	r.client = client
+	r.client = providerData.client
+	r.defaults = providerData.defaults
 }
 
 func (r *shootClusterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var config shootClusterResourceModelV2
+	var config shootClusterResourceModelV3
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	validatePollingConfig(config.Polling, path.Root("polling"), &resp.Diagnostics)
+
 	// No validation if no hibernation schedules defined
 	if config.HibernationSchedules == nil {
 		return
 	}
 
-	// Error if either start or end is null
+	// Error if either start or end is null, or not a valid cron expression
 	for i, schedule := range config.HibernationSchedules {
 		if schedule.Start.IsNull() || schedule.End.IsNull() {
 			resp.Diagnostics.AddAttributeError(
@@ -93,7 +101,7 @@ func (r *shootClusterResource) ValidateConfig(ctx context.Context, req resource.
 				"Expected both Start and End to be configured ",
 			)
 		}
-
+		validateHibernationSchedule(schedule, path.Root("hibernation_schedules").AtListIndex(i), &resp.Diagnostics)
 	}
 
 	var diags diag.Diagnostics
@@ -124,7 +132,6 @@ func (r *shootClusterResource) ValidateConfig(ctx context.Context, req resource.
 		)
 	}
 
-	nameRegex := regexp.MustCompile(`[a-z0-9]([-a-z0-9]*[a-z0-9])?`)
 	// Convert elements to Objects
 	for _, group := range config.ProviderDetails.WorkerGroups.Elements() {
 		objVal, diags := types.ObjectValueFrom(ctx, workerGroupModelAttrTypesV1(), group)
@@ -134,17 +141,29 @@ func (r *shootClusterResource) ValidateConfig(ctx context.Context, req resource.
 		}
 
 		worker := attrValuesToWorkerGroupModelV1(objVal, &resp.Diagnostics)
-		if !nameRegex.Match([]byte(worker.WorkerGroupName.ValueString())) || len(worker.WorkerGroupName.ValueString()) > 6 {
-			resp.Diagnostics.AddError(
-				"Invalid Worker Group Name",
-				"Worker group names must: \n\t1. Only contain lowercase aplhanumeric characters and hyphens\n\t2. Not begin with hyphen or a number\n\t3. Not end with a hyphen \n\t4. Not be longer than 6 characters",
-			)
-		}
+		validateWorkerGroupName(worker.WorkerGroupName, path.Root("provider_details").AtName("worker_groups").AtSetValue(group), &resp.Diagnostics)
 	}
 
 	// If nothing matched, return without warning.
 }
 
+// workerGroupNameRegex matches the worker group names accepted by the API.
+var workerGroupNameRegex = regexp.MustCompile(`[a-z0-9]([-a-z0-9]*[a-z0-9])?`)
+
+// validateWorkerGroupName validates the worker group name of the cluster and the worker group resources.
+func validateWorkerGroupName(name types.String, attributePath path.Path, diags *diag.Diagnostics) {
+	if name.IsNull() || name.IsUnknown() {
+		return
+	}
+	if !workerGroupNameRegex.MatchString(name.ValueString()) || len(name.ValueString()) > 6 {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Worker Group Name",
+			"Worker group names must: \n\t1. Only contain lowercase aplhanumeric characters and hyphens\n\t2. Not begin with hyphen or a number\n\t3. Not end with a hyphen \n\t4. Not be longer than 6 characters",
+		)
+	}
+}
+
 // Metadata returns the resource type name.
 func (r *shootClusterResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_shoot_cluster"
@@ -162,7 +181,7 @@ func (r *shootClusterResource) Schema(ctx context.Context, _ resource.SchemaRequ
 			"name": schema.StringAttribute{
 				Required: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					protectedReplaceString(stringplanmodifier.RequiresReplace()),
 				},
 				Description: "Name of the shoot cluster",
 			},
@@ -171,41 +190,48 @@ func (r *shootClusterResource) Schema(ctx context.Context, _ resource.SchemaRequ
 				Optional:    true,
 				Description: "Enable High-Available deployment of control plane. Once enabled, this option cannot be reversed.",
 				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.RequiresReplaceIf(
+					protectedReplaceBool(boolplanmodifier.RequiresReplaceIf(
 						func(ctx context.Context, sr planmodifier.BoolRequest, rrifr *boolplanmodifier.RequiresReplaceIfFuncResponse) {
 							rrifr.RequiresReplace = sr.StateValue.ValueBool()
 						},
-						"Requires replace only if changing from HA to non-HA", ""),
+						"Requires replace only if changing from HA to non-HA", "")),
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"gardener_domain": schema.StringAttribute{
-				Optional: true,
-				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-				Description: "Gardener domain. Defaults to 'public'",
-				Default:     stringdefault.StaticString("public"),
+				Optional:    true,
+				Computed:    true,
+				Description: "Gardener domain. Defaults to the provider default_gardener_domain, which defaults to 'public'. Requires replace if modified.",
 			},
 			"project": schema.StringAttribute{
-				Required: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-				Description: "Id of the project where cluster will be created.",
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the project where cluster will be created. Defaults to the provider default_project. Requires replace if modified.",
 			},
 			"region": schema.StringAttribute{
-				Required: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-				Description: "One of available regions for the cluster. Depends on the enabled domains in the project",
+				Optional:    true,
+				Computed:    true,
+				Description: "One of available regions for the cluster. Depends on the enabled domains in the project. Defaults to the provider default_region. Requires replace if modified.",
 			},
 			"kubernetes_version": schema.StringAttribute{
 				Optional:    true,
 				Computed:    true,
-				Description: "One of the currently available Kubernetes versions",
+				Description: "One of the currently available Kubernetes versions. Resolved from `kubernetes_version_constraint` if set.",
+			},
+			"kubernetes_version_classification": schema.StringAttribute{
+				Computed:    true,
+				Description: "Classification of `kubernetes_version` in the cloud profile when it was selected, e.g. 'supported' or 'preview'.",
+			},
+			"allow_preview_versions": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Allow selecting preview versions when the Kubernetes version or an image version is not set explicitly, or is resolved from a constraint. Only supported versions are selected by default.",
+			},
+			"kubernetes_version_constraint": schema.StringAttribute{
+				Optional:    true,
+				Description: "Version constraint, e.g. '~> 1.30' or '>= 1.29, < 1.31', resolved to the highest matching supported Kubernetes version. The current version is kept while it matches. Conflicts with `kubernetes_version`.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("kubernetes_version")),
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Computed:    true,
@@ -222,6 +248,102 @@ func (r *shootClusterResource) Schema(ctx context.Context, _ resource.SchemaRequ
 				Computed:    true,
 				Description: "Show current hibernation state of the cluster",
 			},
+			"hibernation_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Desired hibernation state of the cluster. The cluster is hibernated or woken up when this value changes. Hibernation schedules may change the state of the cluster in between, which is shown in `hibernated` and not treated as drift.",
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Prevent the cluster from being destroyed or replaced. Plans destroying or replacing the cluster fail until this is set to false in a prior apply. Defaults to the provider `default_deletion_protection`.",
+			},
+			"ignore_unmanaged_worker_groups": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Ignore worker groups of the cluster not listed in `provider_details.worker_groups`, e.g. managed by `cleura_shoot_worker_group`. By default they are shown as drift and removed by the next apply.",
+			},
+			"polling": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Configure how often the cluster is polled while waiting for an operation to finish. Defaults to the provider polling configuration.",
+				Attributes: map[string]schema.Attribute{
+					"initial_delay": schema.StringAttribute{
+						Optional:    true,
+						Description: pollingAttributeDescriptions["initial_delay"],
+					},
+					"interval": schema.StringAttribute{
+						Optional:    true,
+						Description: pollingAttributeDescriptions["interval"],
+					},
+					"max_interval": schema.StringAttribute{
+						Optional:    true,
+						Description: pollingAttributeDescriptions["max_interval"],
+					},
+				},
+			},
+			"advertised_addresses": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Addresses the cluster is reachable at, e.g. the URL of the Kubernetes API server.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the address, e.g. 'external'",
+						},
+						"url": schema.StringAttribute{
+							Computed:    true,
+							Description: "URL of the address",
+						},
+					},
+				},
+			},
+			"conditions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Conditions of the cluster as last read from the API.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "Type of the condition, e.g. 'APIServerAvailable'",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "Status of the condition, 'True', 'False' or 'Unknown'",
+						},
+						"message": schema.StringAttribute{
+							Computed:    true,
+							Description: "Message describing the condition",
+						},
+					},
+				},
+			},
+			"last_operation": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The last operation Gardener performed on the cluster.",
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Computed:    true,
+						Description: "Type of the operation, e.g. 'Create' or 'Reconcile'",
+					},
+					"state": schema.StringAttribute{
+						Computed:    true,
+						Description: "State of the operation, e.g. 'Processing' or 'Succeeded'",
+					},
+					"progress": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Progress of the operation in percent",
+					},
+					"description": schema.StringAttribute{
+						Computed:    true,
+						Description: "Description of the current step of the operation",
+					},
+					"last_update_time": schema.StringAttribute{
+						Computed:    true,
+						Description: "Time when the operation was last updated",
+					},
+				},
+			},
 			"provider_details": schema.SingleNestedAttribute{
 				Required:    true,
 				Description: "Cluster details.",
@@ -237,7 +359,7 @@ func (r *shootClusterResource) Schema(ctx context.Context, _ resource.SchemaRequ
 						Computed:    true,
 						Description: "The id of the internal OpenStack network to connect worker nodes to. Requires replace if modified.",
 						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
+							protectedReplaceString(stringplanmodifier.RequiresReplace()),
 							stringplanmodifier.UseStateForUnknown(),
 						},
 					},
@@ -246,7 +368,7 @@ func (r *shootClusterResource) Schema(ctx context.Context, _ resource.SchemaRequ
 						Computed:    true,
 						Description: "The id of the OpenStack router to connect the worker subnet to. Requires replace if modified.",
 						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
+							protectedReplaceString(stringplanmodifier.RequiresReplace()),
 							stringplanmodifier.UseStateForUnknown(),
 						},
 					},
@@ -255,14 +377,14 @@ func (r *shootClusterResource) Schema(ctx context.Context, _ resource.SchemaRequ
 						Computed:    true,
 						Description: "The CIDR to use for worker nodes. Cannot overlap with existing subnets in the selected network. Requires replace if modified.",
 						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
+							protectedReplaceString(stringplanmodifier.RequiresReplace()),
 							stringplanmodifier.UseStateForUnknown(),
 						},
 					},
-					"worker_groups": schema.ListNestedAttribute{
+					"worker_groups": schema.SetNestedAttribute{
 						Required:    true,
-						Description: "Defines the worker groups",
-						Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+						Description: "Defines the worker groups, identified by `worker_group_name`. Worker groups of the cluster not listed here are removed, unless `ignore_unmanaged_worker_groups` is set.",
+						Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
 						NestedObject: schema.NestedAttributeObject{
 							Attributes: map[string]schema.Attribute{
 								"worker_group_name": schema.StringAttribute{
@@ -290,9 +412,17 @@ func (r *shootClusterResource) Schema(ctx context.Context, _ resource.SchemaRequ
 								"image_version": schema.StringAttribute{
 									Computed:    true,
 									Optional:    true,
-									Description: "The version of the image of the worker nodes",
-									PlanModifiers: []planmodifier.String{
-										stringplanmodifier.UseStateForUnknown(),
+									Description: "The version of the image of the worker nodes. Defaults to the current version of the worker group, or the latest version for new worker groups.",
+								},
+								"image_version_classification": schema.StringAttribute{
+									Computed:    true,
+									Description: "Classification of `image_version` in the cloud profile when it was selected, e.g. 'supported' or 'preview'.",
+								},
+								"image_version_constraint": schema.StringAttribute{
+									Optional:    true,
+									Description: "Version constraint, e.g. '~> 1443.3', resolved to the highest matching supported version of the image. The current version is kept while it matches. Conflicts with `image_version`.",
+									Validators: []validator.String{
+										stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("image_version")),
 									},
 								},
 								"worker_node_volume_size": schema.StringAttribute{
@@ -349,11 +479,8 @@ func (r *shootClusterResource) Schema(ctx context.Context, _ resource.SchemaRequ
 								"zones": schema.ListAttribute{
 									Computed:    true,
 									Optional:    true,
-									Description: "List of availability zones worker nodes can be scheduled in. Defaults to ['nova']",
+									Description: "List of availability zones worker nodes can be scheduled in. Defaults to the current zones of the worker group, or all zones of the region for new worker groups.",
 									ElementType: types.StringType,
-									PlanModifiers: []planmodifier.List{
-										listplanmodifier.UseStateForUnknown(),
-									},
 								},
 							},
 						},
@@ -374,6 +501,10 @@ func (r *shootClusterResource) Schema(ctx context.Context, _ resource.SchemaRequ
 							Optional:    true,
 							Description: "The time when the hibernation should end in Cron time format",
 						},
+						"location": schema.StringAttribute{
+							Optional:    true,
+							Description: "The time zone `start` and `end` are evaluated in, as an IANA time zone name, e.g. 'Europe/Stockholm'. Defaults to UTC.",
+						},
 					},
 				},
 			},
@@ -409,7 +540,7 @@ func (r *shootClusterResource) Schema(ctx context.Context, _ resource.SchemaRequ
 				},
 			},
 		},
-		Version: 3,
+		Version: 5,
 	}
 }
 
@@ -554,7 +685,7 @@ func (r *shootClusterResource) UpgradeState(ctx context.Context) map[int64]resou
 					return
 				}
 
-				clusterResp, err := r.client.GetShootCluster(
+				clusterResp, err := r.client.GetShootCluster(ctx,
 					"public",
 					priorStateData.Name.ValueString(),
 					priorStateData.Region.ValueString(),
@@ -650,9 +781,10 @@ func (r *shootClusterResource) UpgradeState(ctx context.Context) map[int64]resou
 					haEnabled = true
 				}
 
-				resp.Diagnostics.Append(resp.State.Set(ctx, shootClusterResourceModelV2{
+				upgraded := upgradeShootClusterResourceModelV2(ctx, shootClusterResourceModelV2{
 					Timeouts:             priorStateData.Timeouts,
 					UID:                  priorStateData.UID,
+					Name:                 priorStateData.Name,
 					Region:               priorStateData.Region,
 					Project:              priorStateData.Project,
 					K8sVersion:           priorStateData.K8sVersion,
@@ -662,7 +794,12 @@ func (r *shootClusterResource) UpgradeState(ctx context.Context) map[int64]resou
 					Hibernated:           priorStateData.Hibernated,
 					HibernationSchedules: priorStateData.HibernationSchedules,
 					HaControlPlane:       types.BoolValue(haEnabled),
-				})...)
+					LastOperation:        types.ObjectNull(lastOperationAttrTypes()),
+				}, &resp.Diagnostics)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
 			},
 		},
 		2: {
@@ -815,7 +952,7 @@ func (r *shootClusterResource) UpgradeState(ctx context.Context) map[int64]resou
 					return
 				}
 
-				clusterResp, err := r.client.GetShootCluster(
+				clusterResp, err := r.client.GetShootCluster(ctx,
 					priorStateData.GardenerDomain.ValueString(),
 					priorStateData.Name.ValueString(),
 					priorStateData.Region.ValueString(),
@@ -907,329 +1044,494 @@ func (r *shootClusterResource) UpgradeState(ctx context.Context) map[int64]resou
 					haEnabled = true
 				}
 
-				resp.Diagnostics.Append(resp.State.Set(ctx, shootClusterResourceModelV2{
+				upgraded := upgradeShootClusterResourceModelV2(ctx, shootClusterResourceModelV2{
 					Timeouts:             priorStateData.Timeouts,
 					UID:                  priorStateData.UID,
+					Name:                 priorStateData.Name,
 					Region:               priorStateData.Region,
 					Project:              priorStateData.Project,
 					K8sVersion:           priorStateData.K8sVersion,
 					LastUpdated:          priorStateData.LastUpdated,
-					GardenerDomain:       priorStateData.LastUpdated,
+					GardenerDomain:       priorStateData.GardenerDomain,
 					ProviderDetails:      priorStateData.ProviderDetails,
 					Hibernated:           priorStateData.Hibernated,
 					HibernationSchedules: priorStateData.HibernationSchedules,
+					Maintenance:          priorStateData.Maintenance,
 					HaControlPlane:       types.BoolValue(haEnabled),
-				})...)
+					LastOperation:        types.ObjectNull(lastOperationAttrTypes()),
+				}, &resp.Diagnostics)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
 			},
 		},
+		// State upgrade implementation from 3 to 5, keying the worker groups by name
 		3: {
-			PriorSchema: &schema.Schema{
+			PriorSchema: shootClusterSchemaV3(ctx),
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorStateData shootClusterResourceModelV2
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorStateData)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				upgraded := upgradeShootClusterResourceModelV2(ctx, priorStateData, &resp.Diagnostics)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+		// State upgrade implementation from 4 to 5, adding the conditions and advertised addresses
+		4: {
+			PriorSchema:   shootClusterSchemaV4(ctx),
+			StateUpgrader: upgradeShootClusterStateAddingAttributes,
+		},
+	}
+}
+
+// shootClusterSchemaV3 is the schema of version 3 states, which kept the worker groups in a list.
+func shootClusterSchemaV3(ctx context.Context) *schema.Schema {
+	return &schema.Schema{
+		Attributes: shootClusterAttributesV3(ctx, schema.ListNestedAttribute{
+			Required:    true,
+			Description: "Defines the worker groups",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: workerGroupAttributesV3(),
+			},
+		}),
+		Version: 3,
+	}
+}
+
+// shootClusterSchemaV4 is the schema of version 4 states, which added version constraints, hibernation and
+// deletion protection, and keyed the worker groups by name.
+func shootClusterSchemaV4(ctx context.Context) *schema.Schema {
+	workerGroupAttributes := workerGroupAttributesV3()
+	workerGroupAttributes["image_version_classification"] = schema.StringAttribute{
+		Computed:    true,
+		Description: "Classification of `image_version` in the cloud profile when it was selected",
+	}
+	workerGroupAttributes["image_version_constraint"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Version constraint resolved to the highest matching supported version of the image",
+	}
+
+	attributes := shootClusterAttributesV3(ctx, schema.SetNestedAttribute{
+		Required:    true,
+		Description: "Defines the worker groups, identified by `worker_group_name`",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: workerGroupAttributes,
+		},
+	})
+	attributes["kubernetes_version_classification"] = schema.StringAttribute{
+		Computed:    true,
+		Description: "Classification of `kubernetes_version` in the cloud profile when it was selected",
+	}
+	attributes["kubernetes_version_constraint"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Version constraint resolved to the highest matching supported Kubernetes version",
+	}
+	attributes["allow_preview_versions"] = schema.BoolAttribute{
+		Optional:    true,
+		Description: "Allow selecting preview versions",
+	}
+	attributes["hibernation_enabled"] = schema.BoolAttribute{
+		Optional:    true,
+		Description: "Desired hibernation state of the cluster",
+	}
+	attributes["deletion_protection"] = schema.BoolAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Prevent the cluster from being destroyed or replaced",
+	}
+	attributes["hibernation_schedules"] = schema.ListNestedAttribute{
+		Optional:    true,
+		Description: "An array containing desired hibernation schedules",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"start": schema.StringAttribute{
+					Optional:    true,
+					Description: "The time when the hibernation should start in Cron time format",
+				},
+				"end": schema.StringAttribute{
+					Optional:    true,
+					Description: "The time when the hibernation should end in Cron time format",
+				},
+				"location": schema.StringAttribute{
+					Optional:    true,
+					Description: "The time zone `start` and `end` are evaluated in",
+				},
+			},
+		},
+	}
+
+	return &schema.Schema{
+		Attributes: attributes,
+		Version:    4,
+	}
+}
+
+// shootClusterAttributesV3 returns the attributes of the version 3 schema with the given worker groups.
+func shootClusterAttributesV3(ctx context.Context, workerGroups schema.Attribute) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+			Create: true,
+			Delete: true,
+			Update: true,
+		}),
+		"name": schema.StringAttribute{
+			Required:    true,
+			Description: "Name of the shoot cluster",
+		},
+		"ha_control_plane": schema.BoolAttribute{
+			Computed:    true,
+			Optional:    true,
+			Description: "Enable High-Available deployment of control plane",
+		},
+		"gardener_domain": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Gardener domain",
+		},
+		"project": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Id of the project where cluster will be created",
+		},
+		"region": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "One of available regions for the cluster",
+		},
+		"kubernetes_version": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "One of the currently available Kubernetes versions",
+		},
+		"last_updated": schema.StringAttribute{
+			Computed:    true,
+			Description: "Set local time when cluster resource is created and each time cluster is updated.",
+		},
+		"uid": schema.StringAttribute{
+			Computed:    true,
+			Description: "Unique cluster ID",
+		},
+		"hibernated": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Show current hibernation state of the cluster",
+		},
+		"polling": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "Configure how often the cluster is polled while waiting for an operation to finish",
+			Attributes: map[string]schema.Attribute{
+				"initial_delay": schema.StringAttribute{
+					Optional:    true,
+					Description: pollingAttributeDescriptions["initial_delay"],
+				},
+				"interval": schema.StringAttribute{
+					Optional:    true,
+					Description: pollingAttributeDescriptions["interval"],
+				},
+				"max_interval": schema.StringAttribute{
+					Optional:    true,
+					Description: pollingAttributeDescriptions["max_interval"],
+				},
+			},
+		},
+		"last_operation": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The last operation Gardener performed on the cluster.",
+			Attributes: map[string]schema.Attribute{
+				"type": schema.StringAttribute{
+					Computed:    true,
+					Description: "Type of the operation",
+				},
+				"state": schema.StringAttribute{
+					Computed:    true,
+					Description: "State of the operation",
+				},
+				"progress": schema.Int64Attribute{
+					Computed:    true,
+					Description: "Progress of the operation in percent",
+				},
+				"description": schema.StringAttribute{
+					Computed:    true,
+					Description: "Description of the current step of the operation",
+				},
+				"last_update_time": schema.StringAttribute{
+					Computed:    true,
+					Description: "Time when the operation was last updated",
+				},
+			},
+		},
+		"provider_details": schema.SingleNestedAttribute{
+			Required:    true,
+			Description: "Cluster details.",
+			Attributes: map[string]schema.Attribute{
+				"floating_pool_name": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "The name of the external network to connect to",
+				},
+				"network_id": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "The id of the internal OpenStack network to connect worker nodes to",
+				},
+				"router_id": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "The id of the OpenStack router to connect the worker subnet to",
+				},
+				"worker_cidr": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "The CIDR to use for worker nodes",
+				},
+				"worker_groups": workerGroups,
+			},
+		},
+		"hibernation_schedules": schema.ListNestedAttribute{
+			Optional:    true,
+			Description: "An array containing desired hibernation schedules",
+			NestedObject: schema.NestedAttributeObject{
 				Attributes: map[string]schema.Attribute{
-					"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
-						Create: true,
-						Delete: true,
-						Update: true,
-					}),
-					"name": schema.StringAttribute{
-						Required: true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-						Description: "Name of the shoot cluster",
-					},
-					"gardener_domain": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-						Description: "Gardener domain. Defaults to 'public'",
-						Default:     stringdefault.StaticString("public"),
-					},
-					"project": schema.StringAttribute{
-						Required: true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-						Description: "Id of the project where cluster will be created.",
-					},
-					"region": schema.StringAttribute{
-						Required: true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-						Description: "One of available regions for the cluster. Depends on the enabled domains in the project",
-					},
-					"kubernetes_version": schema.StringAttribute{
+					"start": schema.StringAttribute{
 						Optional:    true,
-						Computed:    true,
-						Description: "One of the currently available Kubernetes versions",
+						Description: "The time when the hibernation should start in Cron time format",
 					},
-					"last_updated": schema.StringAttribute{
-						Computed:    true,
-						Description: "Set local time when cluster resource is created and each time cluster is updated.",
+					"end": schema.StringAttribute{
+						Optional:    true,
+						Description: "The time when the hibernation should end in Cron time format",
 					},
-					"uid": schema.StringAttribute{
-						Computed: true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.UseStateForUnknown(),
-						},
-						Description: "Unique cluster ID",
+				},
+			},
+		},
+		"maintenance": schema.SingleNestedAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Configure maintenance properties",
+			Attributes: map[string]schema.Attribute{
+				"auto_update_kubernetes": schema.BoolAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Toggle wether or not to allow automatic kubernetes upgrades",
+				},
+				"auto_update_machine_image": schema.BoolAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Toggle wether or not to allow automatic machine image upgrades",
+				},
+				"time_window_begin": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Set when time windows for upgrades should begin",
+				},
+				"time_window_end": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Set when time windows for upgrades should end",
+				},
+			},
+		},
+	}
+}
+
+// workerGroupAttributesV3 returns the attributes of a worker group of the version 3 schema.
+func workerGroupAttributesV3() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"worker_group_name": schema.StringAttribute{
+			Required:    true,
+			Description: "Worker group name. Max 6 lowercase alphanumeric characters.",
+		},
+		"min_nodes": schema.Int64Attribute{
+			Required:    true,
+			Description: "The minimum number of worker nodes in the worker group.",
+		},
+		"max_nodes": schema.Int64Attribute{
+			Required:    true,
+			Description: "The maximum number of worker nodes in the worker group",
+		},
+		"machine_type": schema.StringAttribute{
+			Required:    true,
+			Description: "The name of the desired type/flavor of the worker nodes",
+		},
+		"image_name": schema.StringAttribute{
+			Computed:    true,
+			Optional:    true,
+			Description: "The name of the image of the worker nodes",
+		},
+		"image_version": schema.StringAttribute{
+			Computed:    true,
+			Optional:    true,
+			Description: "The version of the image of the worker nodes",
+		},
+		"worker_node_volume_size": schema.StringAttribute{
+			Computed:    true,
+			Optional:    true,
+			Description: "The desired size of the volume used for the worker nodes. Example '50Gi'",
+		},
+		"annotations": schema.MapAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Annotations for taints nodes",
+			ElementType: types.StringType,
+		},
+		"labels": schema.MapAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Labels for worker nodes",
+			ElementType: types.StringType,
+		},
+		"taints": schema.ListNestedAttribute{
+			Optional:    true,
+			Description: "Taints for worker nodes",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"key": schema.StringAttribute{
+						Required:    true,
+						Description: "Key name for taint",
 					},
-					"hibernated": schema.BoolAttribute{
-						Computed:    true,
-						Description: "Show current hibernation state of the cluster",
+					"value": schema.StringAttribute{
+						Required:    true,
+						Description: "Value for taint",
 					},
-					"provider_details": schema.SingleNestedAttribute{
+					"effect": schema.StringAttribute{
 						Required:    true,
-						Description: "Cluster details.",
-						Attributes: map[string]schema.Attribute{
-							"floating_pool_name": schema.StringAttribute{
-								Optional:    true,
-								Computed:    true,
-								Default:     stringdefault.StaticString("ext-net"),
-								Description: "The name of the external network to connect to. Defaults to 'ext-net'.",
-							},
-							"network_id": schema.StringAttribute{
-								Optional:    true,
-								Computed:    true,
-								Description: "The id of the internal OpenStack network to connect worker nodes to. Requires replace if modified.",
-								PlanModifiers: []planmodifier.String{
-									stringplanmodifier.RequiresReplace(),
-									stringplanmodifier.UseStateForUnknown(),
-								},
-							},
-							"router_id": schema.StringAttribute{
-								Optional:    true,
-								Computed:    true,
-								Description: "The id of the OpenStack router to connect the worker subnet to. Requires replace if modified.",
-								PlanModifiers: []planmodifier.String{
-									stringplanmodifier.RequiresReplace(),
-									stringplanmodifier.UseStateForUnknown(),
-								},
-							},
-							"worker_cidr": schema.StringAttribute{
-								Optional:    true,
-								Computed:    true,
-								Description: "The CIDR to use for worker nodes. Cannot overlap with existing subnets in the selected network. Requires replace if modified.",
-								PlanModifiers: []planmodifier.String{
-									stringplanmodifier.RequiresReplace(),
-									stringplanmodifier.UseStateForUnknown(),
-								},
-							},
-							"worker_groups": schema.ListNestedAttribute{
-								Required:    true,
-								Description: "Defines the worker groups",
-								Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
-								NestedObject: schema.NestedAttributeObject{
-									Attributes: map[string]schema.Attribute{
-										"worker_group_name": schema.StringAttribute{
-											Required:    true,
-											Description: "Worker group name. Max 6 lowercase alphanumeric characters.",
-										},
-										"min_nodes": schema.Int64Attribute{
-											Required:    true,
-											Description: "The minimum number of worker nodes in the worker group.",
-										},
-										"max_nodes": schema.Int64Attribute{
-											Required:    true,
-											Description: "The maximum number of worker nodes in the worker group",
-										},
-										"machine_type": schema.StringAttribute{
-											Required:    true,
-											Description: "The name of the desired type/flavor of the worker nodes",
-										},
-										"image_name": schema.StringAttribute{
-											Computed:    true,
-											Optional:    true,
-											Description: "The name of the image of the worker nodes",
-											Default:     stringdefault.StaticString("gardenlinux"),
-										},
-										"image_version": schema.StringAttribute{
-											Computed:    true,
-											Optional:    true,
-											Description: "The version of the image of the worker nodes",
-											PlanModifiers: []planmodifier.String{
-												stringplanmodifier.UseStateForUnknown(),
-											},
-										},
-										"worker_node_volume_size": schema.StringAttribute{
-											Computed:    true,
-											Optional:    true,
-											Description: "The desired size of the volume used for the worker nodes. Example '50Gi'",
-											Default:     stringdefault.StaticString("50Gi"),
-										},
-										"annotations": schema.MapAttribute{
-											Optional:    true,
-											Computed:    true,
-											Description: "Annotations for taints nodes",
-											ElementType: types.StringType,
-											PlanModifiers: []planmodifier.Map{
-												mapplanmodifier.UseStateForUnknown(),
-											},
-											Default: mapdefault.StaticValue(types.MapNull(types.StringType)),
-										},
-										"labels": schema.MapAttribute{
-											Optional:    true,
-											Computed:    true,
-											Description: "Labels for worker nodes",
-											ElementType: types.StringType,
-											PlanModifiers: []planmodifier.Map{
-												mapplanmodifier.UseStateForUnknown(),
-											},
-											Default: mapdefault.StaticValue(types.MapNull(types.StringType)),
-										},
-										"taints": schema.ListNestedAttribute{
-											Optional:    true,
-											Description: "Taints for worker nodes",
-											CustomType:  types.ListType{ElemType: types.ObjectType{AttrTypes: taintAttrTypesV0()}},
-											NestedObject: schema.NestedAttributeObject{
-												Attributes: map[string]schema.Attribute{
-													"key": schema.StringAttribute{
-														Required:    true,
-														Description: "Key name for taint. Must adhere to Kubernetes key naming specifications",
-													},
-													"value": schema.StringAttribute{
-														Required:    true,
-														Description: "Value for taint. Must be within Kubernetes taint value specifications",
-													},
-													"effect": schema.StringAttribute{
-														Required:    true,
-														Description: "Effect for taint. Possible values are 'NoExecute', 'NoSchedule' and 'PreferNoSchedule'",
-														Validators:  []validator.String{stringvalidator.OneOf("NoSchedule", "NoExecute", "PreferNoSchedule")},
-													},
-												},
-											},
-											PlanModifiers: []planmodifier.List{
-												listplanmodifier.UseStateForUnknown(),
-											},
-										},
-										"zones": schema.ListAttribute{
-											Computed:    true,
-											Optional:    true,
-											Description: "List of availability zones worker nodes can be scheduled in. Defaults to ['nova']",
-											ElementType: types.StringType,
-											PlanModifiers: []planmodifier.List{
-												listplanmodifier.UseStateForUnknown(),
-											},
-										},
-									},
-								},
-							},
-						},
-					}, // provider_details end here
-					"hibernation_schedules": schema.ListNestedAttribute{
-						Optional:    true,
-						Description: "An array containing desired hibernation schedules",
-						Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
-						NestedObject: schema.NestedAttributeObject{
-							Attributes: map[string]schema.Attribute{
-								"start": schema.StringAttribute{
-									Optional:    true,
-									Description: "The time when the hibernation should start in Cron time format",
-								},
-								"end": schema.StringAttribute{
-									Optional:    true,
-									Description: "The time when the hibernation should end in Cron time format",
-								},
-							},
-						},
-					},
-					"maintenance": schema.SingleNestedAttribute{
-						Optional:    true,
-						Computed:    true,
-						Description: "Configure maintenance properties",
-						Attributes: map[string]schema.Attribute{
-							"auto_update_kubernetes": schema.BoolAttribute{
-								Optional:    true,
-								Computed:    true,
-								Description: "Toggle wether or not to allow automatic kubernetes upgrades. Defaults to 'true'",
-								Default:     booldefault.StaticBool(true),
-							},
-							"auto_update_machine_image": schema.BoolAttribute{
-								Optional:    true,
-								Computed:    true,
-								Description: "Toggle wether or not to allow automatic machine image upgrades. Defaults to 'true'",
-								Default:     booldefault.StaticBool(true),
-							},
-							"time_window_begin": schema.StringAttribute{
-								Optional:    true,
-								Computed:    true,
-								Description: "Set when time windows for upgrades should begin, defaults to '000000+0100'",
-								Default:     stringdefault.StaticString("000000+0100"),
-							},
-							"time_window_end": schema.StringAttribute{
-								Optional:    true,
-								Computed:    true,
-								Description: "Set when time windows for upgrades should end, defaults to '010000+0100'",
-								Default:     stringdefault.StaticString("010000+0100"),
-							},
-						},
+						Description: "Effect for taint",
 					},
 				},
 			},
-			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
-				var priorStateData shootClusterResourceModelV1
-
-				resp.Diagnostics.Append(req.State.Get(ctx, &priorStateData)...)
-				if resp.Diagnostics.HasError() {
-					return
-				}
-
-				clusterResp, err := r.client.GetShootCluster(
-					priorStateData.GardenerDomain.ValueString(),
-					priorStateData.Name.ValueString(),
-					priorStateData.Region.ValueString(),
-					priorStateData.Project.ValueString(),
-				)
+		},
+		"zones": schema.ListAttribute{
+			Computed:    true,
+			Optional:    true,
+			Description: "List of availability zones worker nodes can be scheduled in",
+			ElementType: types.StringType,
+		},
+	}
+}
 
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Unable to get cluster data",
-						err.Error(),
-					)
-					return
-				}
+// upgradeShootClusterStateAddingAttributes upgrades a state whose schema only lacks attributes of the current
+// schema, which are set to null and refreshed by the next Read.
+func upgradeShootClusterStateAddingAttributes(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var attributes map[string]tftypes.Value
+	if err := req.State.Raw.As(&attributes); err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", "Could not read the prior state: "+err.Error())
+		return
+	}
+	currentType, ok := resp.State.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", "The current schema is not an object. Please report this issue to the provider developers.")
+		return
+	}
+	for name, attributeType := range currentType.AttributeTypes {
+		if _, ok := attributes[name]; !ok {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	resp.State.Raw = tftypes.NewValue(currentType, attributes)
+}
 
-				haEnabled := false
-				if clusterResp.Spec.ControlPlane != (cleura.ControlPlaneDetails{}) {
-					haEnabled = true
-				}
+// upgradeShootClusterResourceModelV2 converts the worker group list of a version 2 or 3 state to a set.
+// Worker group attributes added since version 3 are null.
+func upgradeShootClusterResourceModelV2(ctx context.Context, prior shootClusterResourceModelV2, diags *diag.Diagnostics) shootClusterResourceModelV3 {
+	workerGroupAttrTypes := workerGroupModelAttrTypesV1()
+	var workerGroupValues []attr.Value
+	for _, element := range prior.ProviderDetails.WorkerGroups.Elements() {
+		workerGroup, ok := element.(types.Object)
+		if !ok {
+			diags.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Unexpected worker group value %T in the prior state.", element))
+			return shootClusterResourceModelV3{}
+		}
+		attributes := workerGroup.Attributes()
+		for _, name := range []string{"image_version_constraint", "image_version_classification"} {
+			if _, ok := attributes[name]; !ok {
+				attributes[name] = types.StringNull()
+			}
+		}
+		value, d := types.ObjectValue(workerGroupAttrTypes, attributes)
+		diags.Append(d...)
+		workerGroupValues = append(workerGroupValues, value)
+	}
+	workerGroups, d := types.SetValue(types.ObjectType{AttrTypes: workerGroupAttrTypes}, workerGroupValues)
+	diags.Append(d...)
+
+	var hibernationSchedules []hibernationScheduleModel
+	for _, schedule := range prior.HibernationSchedules {
+		hibernationSchedules = append(hibernationSchedules, hibernationScheduleModel{
+			Start:    schedule.Start,
+			End:      schedule.End,
+			Location: types.StringNull(),
+		})
+	}
 
-				resp.Diagnostics.Append(resp.State.Set(ctx, shootClusterResourceModelV2{
-					Timeouts:             priorStateData.Timeouts,
-					UID:                  priorStateData.UID,
-					Region:               priorStateData.Region,
-					Project:              priorStateData.Project,
-					K8sVersion:           priorStateData.K8sVersion,
-					LastUpdated:          priorStateData.LastUpdated,
-					GardenerDomain:       priorStateData.LastUpdated,
-					ProviderDetails:      priorStateData.ProviderDetails,
-					Hibernated:           priorStateData.Hibernated,
-					HibernationSchedules: priorStateData.HibernationSchedules,
-					HaControlPlane:       types.BoolValue(haEnabled),
-				})...)
-			},
+	return shootClusterResourceModelV3{
+		Timeouts:       prior.Timeouts,
+		UID:            prior.UID,
+		Name:           prior.Name,
+		Region:         prior.Region,
+		Project:        prior.Project,
+		K8sVersion:     prior.K8sVersion,
+		LastUpdated:    prior.LastUpdated,
+		GardenerDomain: prior.GardenerDomain,
+		ProviderDetails: shootProviderDetailsModelV1{
+			FloatingPoolName: prior.ProviderDetails.FloatingPoolName,
+			NetworkId:        prior.ProviderDetails.NetworkId,
+			RouterId:         prior.ProviderDetails.RouterId,
+			WorkerCidr:       prior.ProviderDetails.WorkerCidr,
+			WorkerGroups:     workerGroups,
 		},
+		Hibernated:           prior.Hibernated,
+		HibernationSchedules: hibernationSchedules,
+		Maintenance:          prior.Maintenance,
+		HaControlPlane:       prior.HaControlPlane,
+		LastOperation:        prior.LastOperation,
+		Polling:              prior.Polling,
+		IgnoreUnmanaged:      types.BoolNull(),
+		Conditions:           types.ListNull(types.ObjectType{AttrTypes: conditionsAttrTypes()}),
+		AdvertisedAddresses:  types.ListNull(types.ObjectType{AttrTypes: advertisedAddressesAttrTypes()}),
 	}
 }
 
 func (r *shootClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
 
-	// No plan modification is needed if destroying the resource
+	// No plan modification is needed if destroying the resource, which fails if the cluster is protected
 	if req.Plan.Raw.IsNull() {
+		var name types.String
+		var deletionProtection types.Bool
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &name)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+		if deletionProtection.ValueBool() {
+			addDeletionProtectionError(&resp.Diagnostics, name, "destroying it")
+		}
 		return
 	}
 
-	var plan shootClusterResourceModelV2
+	var plan shootClusterResourceModelV3
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	r.defaults.planLocation(ctx, req, resp, &plan.Project, &plan.Region, &plan.GardenerDomain)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Use the provider default for deletion protection if not set explicitly
+	var deletionProtection types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	if deletionProtection.IsNull() {
+		deletionProtection = types.BoolValue(r.defaults.deletionProtection)
+	}
+	plan.DeletionProtection = deletionProtection
+
 	// Fetch the cloud profile from the API
-	profile, err := r.client.GetCloudProfile(plan.GardenerDomain.ValueString())
+	profile, err := r.client.GetCloudProfile(ctx, plan.GardenerDomain.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to get profile data",
@@ -1238,21 +1540,61 @@ func (r *shootClusterResource) ModifyPlan(ctx context.Context, req resource.Modi
 		return
 	}
 
-	// Use the latest Kubernetes version if not set explicitly
-	if plan.K8sVersion.ValueString() == "" {
-		plan.K8sVersion = getLatestK8sVersion(profile)
+	// Existing worker groups are matched by name, as set elements have no stable position
+	var state shootClusterResourceModelV3
+	exists := !req.State.Raw.IsNull()
+	stateWorkerGroups := make(map[string]workerGroupModelV1)
+	if exists {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, wg := range attrValuesToWorkerGroupModelSlice(state.ProviderDetails.WorkerGroups.Elements(), &resp.Diagnostics) {
+			stateWorkerGroups[wg.WorkerGroupName.ValueString()] = wg
+		}
 	}
 
-	// If not specified by the user, use all availability zones in the given region
-	var availabilityZones []string
-	for _, region := range profile.Spec.Regions {
-		if region.Name == plan.Region.ValueString() {
-			for _, zone := range region.Zones {
-				availabilityZones = append(availabilityZones, zone.Name)
-			}
+	// Validate the values that change against the cloud profile, values in the state may have been
+	// removed from the cloud profile since they were applied. The validation errors are added once
+	// all worker groups are validated.
+	var validation diag.Diagnostics
+	if !exists || !plan.Region.Equal(state.Region) {
+		validateRegion(profile, path.Root("region"), plan.Region, &validation)
+	}
+	if !exists || !plan.K8sVersion.Equal(state.K8sVersion) {
+		validateKubernetesVersion(profile, path.Root("kubernetes_version"), plan.K8sVersion, &validation)
+	}
+
+	// Only supported versions are selected unless preview versions are allowed
+	allowPreview := plan.AllowPreviewVersions.ValueBool()
+
+	// Resolve the Kubernetes version constraint, or use the latest Kubernetes version if not set explicitly
+	if constraint := plan.K8sVersionConstraint; !constraint.IsNull() && !constraint.IsUnknown() {
+		resolved, err := resolveVersionConstraint(profile.Spec.Kubernetes.Versions, constraint.ValueString(), state.K8sVersion, allowPreview)
+		if err != nil {
+			validation.AddAttributeError(path.Root("kubernetes_version_constraint"), "Invalid Kubernetes Version Constraint", err.Error())
+		} else {
+			plan.K8sVersion = types.StringValue(resolved)
 		}
+	} else if plan.K8sVersion.ValueString() == "" && !constraint.IsUnknown() {
+		latest, err := getLatestVersion(profile.Spec.Kubernetes.Versions, allowPreview)
+		if err != nil {
+			validation.AddAttributeError(path.Root("kubernetes_version"), "Unable to Select Kubernetes Version", err.Error())
+		} else {
+			plan.K8sVersion = types.StringValue(latest)
+		}
+	}
+
+	// Report the classification of the selected Kubernetes version, kept while the version is unchanged
+	if exists && plan.K8sVersion.Equal(state.K8sVersion) {
+		plan.K8sClassification = state.K8sClassification
+	} else if !plan.K8sVersion.IsUnknown() {
+		plan.K8sClassification = versionClassification(profile.Spec.Kubernetes.Versions, plan.K8sVersion)
 	}
 
+	// If not specified by the user, use all availability zones in the given region
+	availabilityZones := regionZones(profile, plan.Region.ValueString())
+
 	// Convert elements to Objects
 	var workerGroups []attr.Value
 	for _, group := range plan.ProviderDetails.WorkerGroups.Elements() {
@@ -1265,16 +1607,62 @@ func (r *shootClusterResource) ModifyPlan(ctx context.Context, req resource.Modi
 		workerGroups = append(workerGroups, objVal)
 	}
 
+	workerGroupPaths := configWorkerGroupPaths(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Iterate over all worker groups and set Kubernetes version to latest if not specified
 	for i, wg := range workerGroups {
 		worker := attrValuesToWorkerGroupModelV1(wg, &resp.Diagnostics)
 		if resp.Diagnostics.HasError() {
 			return
 		}
+		current, existing := stateWorkerGroups[worker.WorkerGroupName.ValueString()]
+		workerGroupPath, ok := workerGroupPaths[worker.WorkerGroupName.ValueString()]
+		if !ok {
+			workerGroupPath = path.Root("provider_details").AtName("worker_groups")
+		}
+
+		imageVersions := machineImageVersions(profile, worker.ImageName.ValueString())
+		if constraint := worker.ImageVersionConstraint; !constraint.IsNull() && !constraint.IsUnknown() {
+			// Resolve the image version constraint against the versions of the image
+			resolved, err := resolveVersionConstraint(imageVersions, constraint.ValueString(), current.ImageVersion, allowPreview)
+			if err != nil {
+				validation.AddAttributeError(workerGroupPath.AtName("image_version_constraint"), "Invalid Image Version Constraint", err.Error())
+			} else {
+				worker.ImageVersion = types.StringValue(resolved)
+			}
+		} else if !constraint.IsUnknown() {
+			// Keep the current image version of existing worker groups if not set explicitly
+			if worker.ImageVersion.IsUnknown() && existing {
+				worker.ImageVersion = current.ImageVersion
+			}
 
-		// Use the latest GardenLinux image if not set explicitly
-		if worker.ImageVersion.ValueString() == "" {
-			worker.ImageVersion = getLatestGardenlinuxVersion(profile)
+			// Use the latest version of the image if not set explicitly
+			if worker.ImageVersion.ValueString() == "" {
+				latest, err := getLatestVersion(imageVersions, allowPreview)
+				if err != nil {
+					validation.AddAttributeError(workerGroupPath.AtName("image_version"), "Unable to Select Image Version",
+						fmt.Sprintf("Image %q: %s", worker.ImageName.ValueString(), err))
+				} else {
+					worker.ImageVersion = types.StringValue(latest)
+				}
+			}
+		}
+
+		// Report the classification of the selected image version, kept while the version is unchanged
+		if existing && worker.ImageName.Equal(current.ImageName) && worker.ImageVersion.Equal(current.ImageVersion) {
+			worker.ImageClassification = current.ImageClassification
+		} else if worker.ImageVersion.IsUnknown() {
+			worker.ImageClassification = types.StringUnknown()
+		} else {
+			worker.ImageClassification = versionClassification(imageVersions, worker.ImageVersion)
+		}
+
+		// Keep the current zones of existing worker groups if not set explicitly
+		if worker.Zones.IsUnknown() && existing && !current.Zones.IsNull() {
+			worker.Zones = current.Zones
 		}
 
 		// Set all availability zones if not set explicitly
@@ -1287,6 +1675,16 @@ func (r *shootClusterResource) ModifyPlan(ctx context.Context, req resource.Modi
 			worker.Zones = zones
 		}
 
+		if !existing || !worker.MachineType.Equal(current.MachineType) {
+			validateMachineType(profile, workerGroupPath.AtName("machine_type"), worker.MachineType, &validation)
+		}
+		if !existing || !worker.ImageName.Equal(current.ImageName) || !worker.ImageVersion.Equal(current.ImageVersion) {
+			validateMachineImage(profile, workerGroupPath, worker.ImageName, worker.ImageVersion, &validation)
+		}
+		if !existing || !worker.Zones.Equal(current.Zones) {
+			validateZones(profile, workerGroupPath.AtName("zones"), plan.Region, worker.Zones, &validation)
+		}
+
 		workerGroups[i], diags = types.ObjectValueFrom(ctx, workerGroupModelAttrTypesV1(), worker)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
@@ -1318,8 +1716,22 @@ func (r *shootClusterResource) ModifyPlan(ctx context.Context, req resource.Modi
 		return
 	}
 
+	resp.Diagnostics.Append(validation...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.warnVersionLifecycle(profile, plan.K8sVersion, workerGroups, workerGroupPaths, &resp.Diagnostics)
+
+	// Replacing the cluster destroys it, which fails if the cluster is protected. Replacements required by
+	// the plan modifiers of the schema are checked by protectedReplaceString and protectedReplaceBool.
+	if exists && state.DeletionProtection.ValueBool() && len(resp.RequiresReplace) > 0 {
+		addDeletionProtectionError(&resp.Diagnostics, state.Name, "replacing it, as required by the planned change of "+replacedAttributes(resp.RequiresReplace))
+		return
+	}
+
 	// Set the updated objects to the plan
-	plan.ProviderDetails.WorkerGroups, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: workerGroupModelAttrTypesV1()}, workerGroups)
+	plan.ProviderDetails.WorkerGroups, diags = types.SetValueFrom(ctx, types.ObjectType{AttrTypes: workerGroupModelAttrTypesV1()}, workerGroups)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -1328,61 +1740,290 @@ func (r *shootClusterResource) ModifyPlan(ctx context.Context, req resource.Modi
 	resp.Plan.Set(ctx, plan)
 }
 
+// replacedAttributes lists the attributes whose planned change requires replacement for diagnostics.
+func replacedAttributes(requiresReplace path.Paths) string {
+	replaced := make([]string, 0, len(requiresReplace))
+	for _, p := range requiresReplace {
+		replaced = append(replaced, "`"+p.String()+"`")
+	}
+	return strings.Join(replaced, ", ")
+}
+
+// protectedReplaceString wraps a plan modifier of the shoot cluster schema that may require replacement of
+// the cluster, failing the plan if it does while the cluster has deletion_protection enabled.
+func protectedReplaceString(modifier planmodifier.String) planmodifier.String {
+	return protectedReplaceStringModifier{String: modifier}
+}
+
+type protectedReplaceStringModifier struct {
+	planmodifier.String
+}
+
+func (m protectedReplaceStringModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	m.String.PlanModifyString(ctx, req, resp)
+	if resp.RequiresReplace {
+		checkReplaceProtection(ctx, req.State, req.Path, &resp.Diagnostics)
+	}
+}
+
+// protectedReplaceBool is protectedReplaceString for bool attributes.
+func protectedReplaceBool(modifier planmodifier.Bool) planmodifier.Bool {
+	return protectedReplaceBoolModifier{Bool: modifier}
+}
+
+type protectedReplaceBoolModifier struct {
+	planmodifier.Bool
+}
+
+func (m protectedReplaceBoolModifier) PlanModifyBool(ctx context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	m.Bool.PlanModifyBool(ctx, req, resp)
+	if resp.RequiresReplace {
+		checkReplaceProtection(ctx, req.State, req.Path, &resp.Diagnostics)
+	}
+}
+
+// checkReplaceProtection adds an error if the cluster in state has deletion_protection enabled, as the
+// planned change of the attribute at attributePath replaces it.
+func checkReplaceProtection(ctx context.Context, state tfsdk.State, attributePath path.Path, diags *diag.Diagnostics) {
+	if state.Raw.IsNull() {
+		return
+	}
+	var name types.String
+	var protected types.Bool
+	diags.Append(state.GetAttribute(ctx, path.Root("name"), &name)...)
+	diags.Append(state.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	if protected.ValueBool() {
+		addDeletionProtectionError(diags, name, "replacing it, as required by the planned change of "+replacedAttributes(path.Paths{attributePath}))
+	}
+}
+
+func addDeletionProtectionError(diags *diag.Diagnostics, name types.String, action string) {
+	diags.AddAttributeError(
+		path.Root("deletion_protection"),
+		"Cluster Is Protected From Deletion",
+		fmt.Sprintf("Cluster %q has `deletion_protection` enabled, which prevents %s. "+
+			"Set `deletion_protection` to false and apply the change first.", name.ValueString(), action),
+	)
+}
+
+// warnVersionLifecycle warns about the planned Kubernetes version and worker group image versions that are
+// deprecated or about to expire.
+func (r *shootClusterResource) warnVersionLifecycle(profile *cleura.CloudProfile, k8sVersion types.String, workerGroups []attr.Value, workerGroupPaths map[string]path.Path, diags *diag.Diagnostics) {
+	window := r.defaults.versionExpirationWarning
+	warnVersionLifecycle(profile.Spec.Kubernetes.Versions, path.Root("kubernetes_version"), "Kubernetes Version", "Kubernetes version", k8sVersion, window, diags)
+
+	for _, worker := range attrValuesToWorkerGroupModelSlice(workerGroups, diags) {
+		workerGroupPath, ok := workerGroupPaths[worker.WorkerGroupName.ValueString()]
+		if !ok {
+			workerGroupPath = path.Root("provider_details").AtName("worker_groups")
+		}
+		for _, image := range profile.Spec.MachineImages {
+			if image.Name == worker.ImageName.ValueString() {
+				warnVersionLifecycle(image.Versions, workerGroupPath.AtName("image_version"), "Image Version",
+					fmt.Sprintf("Version of image %q of worker group %q", image.Name, worker.WorkerGroupName.ValueString()), worker.ImageVersion, window, diags)
+			}
+		}
+	}
+}
+
+// keepPlannedWorkerGroupAttributes copies the image version constraints and classifications of the planned
+// worker groups to the worker groups built from the API response, which does not know about them.
+func keepPlannedWorkerGroupAttributes(workerGroups []attr.Value, planned []workerGroupModelV1, diags *diag.Diagnostics) []attr.Value {
+	plannedByName := make(map[string]workerGroupModelV1)
+	for _, wg := range planned {
+		plannedByName[wg.WorkerGroupName.ValueString()] = wg
+	}
+
+	result := make([]attr.Value, 0, len(workerGroups))
+	for _, group := range workerGroups {
+		obj, ok := group.(types.Object)
+		if !ok {
+			diags.AddError("Invalid Value", "Expected Object in list")
+			continue
+		}
+		attributes := maps.Clone(obj.Attributes())
+		name, _ := attributes["worker_group_name"].(types.String)
+		wg, ok := plannedByName[name.ValueString()]
+		if !ok {
+			wg = workerGroupModelV1{ImageVersionConstraint: types.StringNull(), ImageClassification: types.StringNull()}
+		}
+		attributes["image_version_constraint"] = wg.ImageVersionConstraint
+		attributes["image_version_classification"] = wg.ImageClassification
+
+		updated, d := types.ObjectValue(workerGroupModelAttrTypesV1(), attributes)
+		diags.Append(d...)
+		result = append(result, updated)
+	}
+	return result
+}
+
+// refreshShootClusterModel sets the attributes of the model that are read from the API. Create, Read, Update
+// and ImportState all use it, so every attribute is refreshed the same way and changes made outside of
+// Terraform show up as drift in the plan. With ignoreUnmanaged only the managed worker groups are stored, so
+// worker groups managed elsewhere, e.g. by cleura_shoot_worker_group, are ignored. Otherwise all worker groups
+// are stored and unmanaged ones show up as drift. Worker group attributes the API does not know about are kept
+// from the managed worker groups.
+func refreshShootClusterModel(ctx context.Context, model *shootClusterResourceModelV3, shoot *shootClusterDetails, managed []workerGroupModelV1, ignoreUnmanaged bool, diags *diag.Diagnostics) {
+	var d diag.Diagnostics
+
+	model.UID = types.StringValue(shoot.Metadata.UID)
+	model.Name = types.StringValue(shoot.Metadata.Name)
+	model.K8sVersion = types.StringValue(shoot.Spec.Kubernetes.Version)
+	model.HaControlPlane = types.BoolValue(shoot.Spec.ControlPlane != (cleura.ControlPlaneDetails{}))
+	model.Hibernated = types.BoolValue(shoot.Status.Hibernated)
+
+	model.ProviderDetails.FloatingPoolName = types.StringValue(shoot.Spec.Provider.InfrastructureConfig.FloatingPoolName)
+	model.ProviderDetails.NetworkId = types.StringValue(shoot.Spec.Provider.InfrastructureConfig.Networks.Id)
+	model.ProviderDetails.RouterId = types.StringValue(shoot.Spec.Provider.InfrastructureConfig.Networks.Router.Id)
+	model.ProviderDetails.WorkerCidr = types.StringValue(shoot.Spec.Provider.InfrastructureConfig.Networks.WorkersCIDR)
+
+	managedNames := make(map[string]bool)
+	for _, wg := range managed {
+		managedNames[wg.WorkerGroupName.ValueString()] = true
+	}
+	workerGroups := make([]attr.Value, 0, len(shoot.Spec.Provider.Workers))
+	for _, worker := range shoot.Spec.Provider.Workers {
+		if ignoreUnmanaged && !managedNames[worker.Name] {
+			continue
+		}
+		obj, d := cleuraWorkerToObjectValue(ctx, worker)
+		diags.Append(d...)
+		workerGroups = append(workerGroups, obj)
+	}
+	workerGroups = keepPlannedWorkerGroupAttributes(workerGroups, managed, diags)
+	model.ProviderDetails.WorkerGroups, d = types.SetValue(types.ObjectType{AttrTypes: workerGroupModelAttrTypesV1()}, workerGroups)
+	diags.Append(d...)
+
+	model.HibernationSchedules = hibernationSchedulesFromResponse(shoot.Spec.Hibernation.HibernationResponseSchedules)
+
+	model.Maintenance, d = types.ObjectValueFrom(ctx, maintenanceAttrTypesV0(), maintenanceModel{
+		AutoUpdateKubernetes:   types.BoolValue(shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion),
+		AutoUpdateMachineImage: types.BoolValue(shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion),
+		TimeWindowBegin:        types.StringValue(shoot.Spec.Maintenance.TimeWindow.Begin),
+		TimeWindowEnd:          types.StringValue(shoot.Spec.Maintenance.TimeWindow.End),
+	})
+	diags.Append(d...)
+
+	model.Conditions, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: conditionsAttrTypes()}, shootClusterConditions(shoot.ShootClusterResponse))
+	diags.Append(d...)
+	model.AdvertisedAddresses, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: advertisedAddressesAttrTypes()}, shootClusterAdvertisedAddresses(shoot.ShootClusterResponse))
+	diags.Append(d...)
+
+	model.LastOperation, d = lastOperationObjectValue(ctx, shoot)
+	diags.Append(d...)
+}
+
+// configWorkerGroupPaths maps the names of the configured worker groups to their attribute paths, so
+// diagnostics of a worker group point to its configuration. Worker groups with an unknown name are not mapped.
+func configWorkerGroupPaths(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) map[string]path.Path {
+	workerGroupsPath := path.Root("provider_details").AtName("worker_groups")
+	paths := make(map[string]path.Path)
+
+	var workerGroups types.Set
+	diags.Append(config.GetAttribute(ctx, workerGroupsPath, &workerGroups)...)
+	for _, group := range workerGroups.Elements() {
+		obj, ok := group.(types.Object)
+		if !ok {
+			continue
+		}
+		name, ok := obj.Attributes()["worker_group_name"].(types.String)
+		if !ok || name.IsNull() || name.IsUnknown() {
+			continue
+		}
+		paths[name.ValueString()] = workerGroupsPath.AtSetValue(group)
+	}
+	return paths
+}
+
 type shootClusterResourceModelV0 struct {
-	Timeouts             timeouts.Value             `tfsdk:"timeouts"`
-	UID                  types.String               `tfsdk:"uid"`
-	Name                 types.String               `tfsdk:"name"`
-	Region               types.String               `tfsdk:"region"`
-	Project              types.String               `tfsdk:"project"`
-	K8sVersion           types.String               `tfsdk:"kubernetes_version"`
-	LastUpdated          types.String               `tfsdk:"last_updated"`
-	ProviderDetails      shootProviderDetailsModel  `tfsdk:"provider_details"`
-	Hibernated           types.Bool                 `tfsdk:"hibernated"`
-	HibernationSchedules []hibernationScheduleModel `tfsdk:"hibernation_schedules"`
+	Timeouts             timeouts.Value               `tfsdk:"timeouts"`
+	UID                  types.String                 `tfsdk:"uid"`
+	Name                 types.String                 `tfsdk:"name"`
+	Region               types.String                 `tfsdk:"region"`
+	Project              types.String                 `tfsdk:"project"`
+	K8sVersion           types.String                 `tfsdk:"kubernetes_version"`
+	LastUpdated          types.String                 `tfsdk:"last_updated"`
+	ProviderDetails      shootProviderDetailsModel    `tfsdk:"provider_details"`
+	Hibernated           types.Bool                   `tfsdk:"hibernated"`
+	HibernationSchedules []hibernationScheduleModelV0 `tfsdk:"hibernation_schedules"`
 	// Conditions          []shootClusterConditionsModel          `tfsdk:"conditions"`
 	// AdvertisedAddresses []shootClusterAdvertisedAddressesModel `tfsdk:"advertised_addresses"`
 }
 
 type shootClusterResourceModelV1 struct {
-	Timeouts             timeouts.Value             `tfsdk:"timeouts"`
-	UID                  types.String               `tfsdk:"uid"`
-	Name                 types.String               `tfsdk:"name"`
-	Region               types.String               `tfsdk:"region"`
-	Project              types.String               `tfsdk:"project"`
-	K8sVersion           types.String               `tfsdk:"kubernetes_version"`
-	LastUpdated          types.String               `tfsdk:"last_updated"`
-	GardenerDomain       types.String               `tfsdk:"gardener_domain"`
-	ProviderDetails      shootProviderDetailsModel  `tfsdk:"provider_details"`
-	Hibernated           types.Bool                 `tfsdk:"hibernated"`
-	HibernationSchedules []hibernationScheduleModel `tfsdk:"hibernation_schedules"`
-	Maintenance          types.Object               `tfsdk:"maintenance"`
+	Timeouts             timeouts.Value               `tfsdk:"timeouts"`
+	UID                  types.String                 `tfsdk:"uid"`
+	Name                 types.String                 `tfsdk:"name"`
+	Region               types.String                 `tfsdk:"region"`
+	Project              types.String                 `tfsdk:"project"`
+	K8sVersion           types.String                 `tfsdk:"kubernetes_version"`
+	LastUpdated          types.String                 `tfsdk:"last_updated"`
+	GardenerDomain       types.String                 `tfsdk:"gardener_domain"`
+	ProviderDetails      shootProviderDetailsModel    `tfsdk:"provider_details"`
+	Hibernated           types.Bool                   `tfsdk:"hibernated"`
+	HibernationSchedules []hibernationScheduleModelV0 `tfsdk:"hibernation_schedules"`
+	Maintenance          types.Object                 `tfsdk:"maintenance"`
 	// Conditions          []shootClusterConditionsModel          `tfsdk:"conditions"`
 	// AdvertisedAddresses []shootClusterAdvertisedAddressesModel `tfsdk:"advertised_addresses"`
 }
 
 type shootClusterResourceModelV2 struct {
-	Timeouts             timeouts.Value             `tfsdk:"timeouts"`
-	UID                  types.String               `tfsdk:"uid"`
-	Name                 types.String               `tfsdk:"name"`
-	Region               types.String               `tfsdk:"region"`
-	Project              types.String               `tfsdk:"project"`
-	K8sVersion           types.String               `tfsdk:"kubernetes_version"`
-	LastUpdated          types.String               `tfsdk:"last_updated"`
-	GardenerDomain       types.String               `tfsdk:"gardener_domain"`
-	ProviderDetails      shootProviderDetailsModel  `tfsdk:"provider_details"`
-	Hibernated           types.Bool                 `tfsdk:"hibernated"`
-	HibernationSchedules []hibernationScheduleModel `tfsdk:"hibernation_schedules"`
-	Maintenance          types.Object               `tfsdk:"maintenance"`
-	HaControlPlane       types.Bool                 `tfsdk:"ha_control_plane"`
+	Timeouts             timeouts.Value               `tfsdk:"timeouts"`
+	UID                  types.String                 `tfsdk:"uid"`
+	Name                 types.String                 `tfsdk:"name"`
+	Region               types.String                 `tfsdk:"region"`
+	Project              types.String                 `tfsdk:"project"`
+	K8sVersion           types.String                 `tfsdk:"kubernetes_version"`
+	LastUpdated          types.String                 `tfsdk:"last_updated"`
+	GardenerDomain       types.String                 `tfsdk:"gardener_domain"`
+	ProviderDetails      shootProviderDetailsModel    `tfsdk:"provider_details"`
+	Hibernated           types.Bool                   `tfsdk:"hibernated"`
+	HibernationSchedules []hibernationScheduleModelV0 `tfsdk:"hibernation_schedules"`
+	Maintenance          types.Object                 `tfsdk:"maintenance"`
+	HaControlPlane       types.Bool                   `tfsdk:"ha_control_plane"`
+	LastOperation        types.Object                 `tfsdk:"last_operation"`
+	Polling              *pollingModel                `tfsdk:"polling"`
 	// Conditions          []shootClusterConditionsModel          `tfsdk:"conditions"`
 	// AdvertisedAddresses []shootClusterAdvertisedAddressesModel `tfsdk:"advertised_addresses"`
 }
 
-type hibernationScheduleModel struct {
+type shootClusterResourceModelV3 struct {
+	Timeouts             timeouts.Value              `tfsdk:"timeouts"`
+	UID                  types.String                `tfsdk:"uid"`
+	Name                 types.String                `tfsdk:"name"`
+	Region               types.String                `tfsdk:"region"`
+	Project              types.String                `tfsdk:"project"`
+	K8sVersion           types.String                `tfsdk:"kubernetes_version"`
+	K8sVersionConstraint types.String                `tfsdk:"kubernetes_version_constraint"`
+	K8sClassification    types.String                `tfsdk:"kubernetes_version_classification"`
+	AllowPreviewVersions types.Bool                  `tfsdk:"allow_preview_versions"`
+	LastUpdated          types.String                `tfsdk:"last_updated"`
+	GardenerDomain       types.String                `tfsdk:"gardener_domain"`
+	ProviderDetails      shootProviderDetailsModelV1 `tfsdk:"provider_details"`
+	Hibernated           types.Bool                  `tfsdk:"hibernated"`
+	HibernationEnabled   types.Bool                  `tfsdk:"hibernation_enabled"`
+	HibernationSchedules []hibernationScheduleModel  `tfsdk:"hibernation_schedules"`
+	Maintenance          types.Object                `tfsdk:"maintenance"`
+	HaControlPlane       types.Bool                  `tfsdk:"ha_control_plane"`
+	LastOperation        types.Object                `tfsdk:"last_operation"`
+	Polling              *pollingModel               `tfsdk:"polling"`
+	DeletionProtection   types.Bool                  `tfsdk:"deletion_protection"`
+	IgnoreUnmanaged      types.Bool                  `tfsdk:"ignore_unmanaged_worker_groups"`
+	Conditions           types.List                  `tfsdk:"conditions"`
+	AdvertisedAddresses  types.List                  `tfsdk:"advertised_addresses"`
+}
+
+type hibernationScheduleModelV0 struct {
 	Start types.String `tfsdk:"start"`
 	End   types.String `tfsdk:"end"`
 }
 
+type hibernationScheduleModel struct {
+	Start    types.String `tfsdk:"start"`
+	End      types.String `tfsdk:"end"`
+	Location types.String `tfsdk:"location"`
+}
+
 type maintenanceModel struct {
 	AutoUpdateKubernetes   types.Bool   `tfsdk:"auto_update_kubernetes"`
 	AutoUpdateMachineImage types.Bool   `tfsdk:"auto_update_machine_image"`
@@ -1398,18 +2039,29 @@ type shootProviderDetailsModel struct {
 	WorkerGroups     types.List   `tfsdk:"worker_groups"`
 }
 
+// shootProviderDetailsModelV1 keeps the worker groups in a set, matched by worker_group_name.
+type shootProviderDetailsModelV1 struct {
+	FloatingPoolName types.String `tfsdk:"floating_pool_name"`
+	NetworkId        types.String `tfsdk:"network_id"`
+	RouterId         types.String `tfsdk:"router_id"`
+	WorkerCidr       types.String `tfsdk:"worker_cidr"`
+	WorkerGroups     types.Set    `tfsdk:"worker_groups"`
+}
+
 type workerGroupModelV1 struct {
-	WorkerGroupName types.String `tfsdk:"worker_group_name"`
-	MachineType     types.String `tfsdk:"machine_type"`
-	ImageName       types.String `tfsdk:"image_name"`
-	ImageVersion    types.String `tfsdk:"image_version"`
-	VolumeSize      types.String `tfsdk:"worker_node_volume_size"`
-	MinNodes        types.Int64  `tfsdk:"min_nodes"`
-	MaxNodes        types.Int64  `tfsdk:"max_nodes"`
-	Annotations     types.Map    `tfsdk:"annotations"`
-	Labels          types.Map    `tfsdk:"labels"`
-	Taints          types.List   `tfsdk:"taints"`
-	Zones           types.List   `tfsdk:"zones"`
+	WorkerGroupName        types.String `tfsdk:"worker_group_name"`
+	MachineType            types.String `tfsdk:"machine_type"`
+	ImageName              types.String `tfsdk:"image_name"`
+	ImageVersion           types.String `tfsdk:"image_version"`
+	ImageVersionConstraint types.String `tfsdk:"image_version_constraint"`
+	ImageClassification    types.String `tfsdk:"image_version_classification"`
+	VolumeSize             types.String `tfsdk:"worker_node_volume_size"`
+	MinNodes               types.Int64  `tfsdk:"min_nodes"`
+	MaxNodes               types.Int64  `tfsdk:"max_nodes"`
+	Annotations            types.Map    `tfsdk:"annotations"`
+	Labels                 types.Map    `tfsdk:"labels"`
+	Taints                 types.List   `tfsdk:"taints"`
+	Zones                  types.List   `tfsdk:"zones"`
 }
 
 type KeyValuePair struct {
@@ -1437,17 +2089,19 @@ func workerGroupModelAttrTypesV0() map[string]attr.Type {
 
 func workerGroupModelAttrTypesV1() map[string]attr.Type {
 	return map[string]attr.Type{
-		"worker_group_name":       types.StringType,
-		"machine_type":            types.StringType,
-		"image_name":              types.StringType,
-		"image_version":           types.StringType,
-		"worker_node_volume_size": types.StringType,
-		"min_nodes":               types.Int64Type,
-		"max_nodes":               types.Int64Type,
-		"annotations":             types.MapType{ElemType: types.StringType},
-		"labels":                  types.MapType{ElemType: types.StringType},
-		"taints":                  types.ListType{ElemType: types.ObjectType{AttrTypes: taintAttrTypesV0()}},
-		"zones":                   types.ListType{ElemType: types.StringType},
+		"worker_group_name":            types.StringType,
+		"machine_type":                 types.StringType,
+		"image_name":                   types.StringType,
+		"image_version":                types.StringType,
+		"image_version_constraint":     types.StringType,
+		"image_version_classification": types.StringType,
+		"worker_node_volume_size":      types.StringType,
+		"min_nodes":                    types.Int64Type,
+		"max_nodes":                    types.Int64Type,
+		"annotations":                  types.MapType{ElemType: types.StringType},
+		"labels":                       types.MapType{ElemType: types.StringType},
+		"taints":                       types.ListType{ElemType: types.ObjectType{AttrTypes: taintAttrTypesV0()}},
+		"zones":                        types.ListType{ElemType: types.StringType},
 	}
 }
 
@@ -1468,6 +2122,21 @@ func maintenanceAttrTypesV0() map[string]attr.Type {
 	}
 }
 
+func conditionsAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"type":    types.StringType,
+		"status":  types.StringType,
+		"message": types.StringType,
+	}
+}
+
+func advertisedAddressesAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name": types.StringType,
+		"url":  types.StringType,
+	}
+}
+
 func int16Downcast(num int64) (int16, error) {
 	if num > math.MaxInt16 || num < math.MinInt16 {
 		return 0, fmt.Errorf("value %d cannot be downcasted to int16 as it would overflow", num)
@@ -1594,6 +2263,12 @@ func attrValuesToWorkerGroupModelV1(value attr.Value, diags *diag.Diagnostics) w
 	workerGroup.ImageVersion, err = getStringAttr("image_version", value)
 	diags.Append(err...)
 
+	workerGroup.ImageVersionConstraint, err = getStringAttr("image_version_constraint", value)
+	diags.Append(err...)
+
+	workerGroup.ImageClassification, err = getStringAttr("image_version_classification", value)
+	diags.Append(err...)
+
 	workerGroup.VolumeSize, err = getStringAttr("worker_node_volume_size", value)
 	diags.Append(err...)
 
@@ -1851,57 +2526,10 @@ func cleuraWorkerToObjectValue(ctx context.Context, worker cleura.WorkerUpdateRe
 	return objVal, diags
 }
 
-func cleuraWorkerCreateToObjectValue(ctx context.Context, worker cleura.WorkerCreateResponse) (types.Object, diag.Diagnostics) {
-	var diags diag.Diagnostics
-
-	var annotationsMap map[string]string
-	if len(worker.Annotations) > 0 {
-		annotationsMap = make(map[string]string)
-		for _, annotation := range worker.Annotations {
-			annotationsMap[annotation.Key] = annotation.Value
-		}
-	}
-	annotations, err := types.MapValueFrom(ctx, types.StringType, annotationsMap)
-	diags.Append(err...)
-
-	var labelsMap map[string]string
-	if len(worker.Labels) > 0 {
-		labelsMap = make(map[string]string)
-		for _, label := range worker.Labels {
-			labelsMap[label.Key] = label.Value
-		}
-	}
-	labels, err := types.MapValueFrom(ctx, types.StringType, labelsMap)
-	diags.Append(err...)
-
-	taints, err := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: taintAttrTypesV0()}, cleuraTaintListToTaintList(worker.Taints))
-	diags.Append(err...)
-
-	zones, err := types.ListValueFrom(ctx, types.StringType, worker.Zones)
-	diags.Append(err...)
-
-	objVal, err := types.ObjectValueFrom(ctx, workerGroupModelAttrTypesV1(), workerGroupModelV1{
-		WorkerGroupName: types.StringValue(worker.Name),
-		MachineType:     types.StringValue(worker.Machine.Type),
-		ImageName:       types.StringValue(worker.Machine.Image.Name),
-		ImageVersion:    types.StringValue(worker.Machine.Image.Version),
-		VolumeSize:      types.StringValue(worker.Volume.Size),
-		MinNodes:        types.Int64Value(int64(worker.Minimum)),
-		MaxNodes:        types.Int64Value(int64(worker.Maximum)),
-		Annotations:     annotations,
-		Labels:          labels,
-		Taints:          taints,
-		Zones:           zones,
-	})
-	diags.Append(err...)
-
-	return objVal, diags
-}
-
 // Create creates the resource and sets the initial Terraform state.
 func (r *shootClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Debug(ctx, "XXX_CREATE")
-	var plan shootClusterResourceModelV2
+	var plan shootClusterResourceModelV3
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -1915,6 +2543,27 @@ func (r *shootClusterResource) Create(ctx context.Context, req resource.CreateRe
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	polling := newPollingSchedule(plan.Polling, r.defaults.polling, path.Root("polling"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := clusterKey{
+		gardenerDomain: plan.GardenerDomain.ValueString(),
+		region:         plan.Region.ValueString(),
+		project:        plan.Project.ValueString(),
+		name:           plan.Name.ValueString(),
+	}
+	unlock, err := lockCluster(ctx, key)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating shoot cluster",
+			"Could not acquire the shoot cluster operation lock: "+err.Error(),
+		)
+		return
+	}
+	defer unlock()
+
 	var workerGroups []attr.Value
 	for _, group := range plan.ProviderDetails.WorkerGroups.Elements() {
 		objVal, diags := types.ObjectValueFrom(ctx, workerGroupModelAttrTypesV1(), group)
@@ -1939,11 +2588,12 @@ func (r *shootClusterResource) Create(ctx context.Context, req resource.CreateRe
 		clusterWorkers = append(clusterWorkers, workerGroupRequest)
 	}
 	// Mapping hibernation schedules
-	var hibernationSchedules []cleura.HibernationSchedule
+	var hibernationSchedules []hibernationScheduleRequest
 	for _, schedule := range plan.HibernationSchedules {
-		hibernationSchedules = append(hibernationSchedules, cleura.HibernationSchedule{
-			Start: schedule.Start.ValueString(),
-			End:   schedule.End.ValueString(),
+		hibernationSchedules = append(hibernationSchedules, hibernationScheduleRequest{
+			Start:    schedule.Start.ValueString(),
+			End:      schedule.End.ValueString(),
+			Location: schedule.Location.ValueString(),
 		},
 		)
 	}
@@ -1970,8 +2620,8 @@ func (r *shootClusterResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	//------------------------------
-	clusterRequest := cleura.ShootClusterRequest{
-		Shoot: cleura.ShootClusterRequestConfig{
+	clusterRequest := shootClusterRequest{
+		Shoot: shootClusterRequestConfig{ShootClusterRequestConfig: cleura.ShootClusterRequestConfig{
 			Name: plan.Name.ValueString(),
 			KubernetesVersion: &cleura.K8sVersion{
 				Version: plan.K8sVersion.ValueString(),
@@ -1994,14 +2644,14 @@ func (r *shootClusterResource) Create(ctx context.Context, req resource.CreateRe
 				},
 			},
 			EnableHaControlPlane: plan.HaControlPlane.ValueBool(),
-		},
+		}},
 	}
 	tflog.Debug(ctx, fmt.Sprintf("Here's clusterRequest: %+v", clusterRequest))
 	// Hibernation must be set to nil(or omitted in clusterRequest) if no schedules defined in config
 	if len(hibernationSchedules) > 0 {
 		tflog.Debug(ctx, fmt.Sprintf("Hibernation schedules count is: %v", len(hibernationSchedules)))
-		clusterRequest.Shoot.Hibernation = &cleura.HibernationSchedules{
-			HibernationSchedules: hibernationSchedules,
+		clusterRequest.Shoot.Hibernation = &hibernationSchedulesRequest{
+			Schedules: hibernationSchedules,
 		}
 		tflog.Debug(ctx, "Hibernation schedules are set")
 	}
@@ -2013,7 +2663,7 @@ func (r *shootClusterResource) Create(ctx context.Context, req resource.CreateRe
 	}
 	tflog.Debug(ctx, fmt.Sprintf("clusterRequest: %v", string(jsonByte)))
 
-	shootResponse, err := r.client.CreateShootCluster(plan.GardenerDomain.ValueString(), plan.Region.ValueString(), plan.Project.ValueString(), clusterRequest)
+	_, err = r.client.CreateShootCluster(ctx, plan.GardenerDomain.ValueString(), plan.Region.ValueString(), plan.Project.ValueString(), clusterRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating shoot cluster",
@@ -2021,34 +2671,9 @@ func (r *shootClusterResource) Create(ctx context.Context, req resource.CreateRe
 		)
 		return
 	}
-	// Populating Computed fields
-	plan.UID = types.StringValue(shootResponse.Shoot.UID)
-	plan.Hibernated = types.BoolValue(shootResponse.Shoot.Hibernation.Enabled)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
-	// Set computed network field
-	plan.ProviderDetails.NetworkId = types.StringValue(shootResponse.Shoot.Provider.InfrastructureConfig.Networks.Id)
-	plan.ProviderDetails.RouterId = types.StringValue(shootResponse.Shoot.Provider.InfrastructureConfig.Networks.Router.Id)
-	plan.ProviderDetails.WorkerCidr = types.StringValue(shootResponse.Shoot.Provider.InfrastructureConfig.Networks.WorkersCIDR)
-
-	// Reset the current worker groups value and store the computed values
-	workerGroups = make([]attr.Value, 0)
-	for _, worker := range shootResponse.Shoot.Provider.Workers {
-		obj, diags := cleuraWorkerCreateToObjectValue(ctx, worker)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		workerGroups = append(workerGroups, obj)
-	}
-
-	plan.ProviderDetails.WorkerGroups, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: workerGroupModelAttrTypesV1()}, workerGroups)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	err = clusterReadyOperationWaiter(r.client, ctx, createTimeout, plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString())
+	err = clusterReadyWaiter(r.client, ctx, polling, key)
 	if err != nil {
 		resp.Diagnostics.AddError(
 
@@ -2058,8 +2683,16 @@ func (r *shootClusterResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	// Fetch updated information about the cluster to get an accurate reading on control-plane HA status
-	getShootResponse, err := r.client.GetShootCluster(plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString())
+	// Hibernate the new cluster if requested
+	if plan.HibernationEnabled.ValueBool() {
+		r.setHibernation(ctx, polling, key, true, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Fetch the created cluster, as the create response lacks e.g. the control-plane HA status
+	getShootResponse, err := r.client.GetShootClusterDetails(ctx, plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Shoot cluster",
@@ -2068,12 +2701,12 @@ func (r *shootClusterResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	haEnabled := false
-	if getShootResponse.Spec.ControlPlane != (cleura.ControlPlaneDetails{}) {
-		haEnabled = true
+	// Only the planned worker groups are stored, as the applied state must match the plan. Unmanaged worker
+	// groups show up as drift on the next Read.
+	refreshShootClusterModel(ctx, &plan, getShootResponse, attrValuesToWorkerGroupModelSlice(workerGroups, &resp.Diagnostics), true, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-
-	plan.HaControlPlane = types.BoolValue(haEnabled)
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -2082,97 +2715,59 @@ func (r *shootClusterResource) Create(ctx context.Context, req resource.CreateRe
 	}
 }
 
-func clusterReconcileWaiter(client *cleura.Client, ctx context.Context, maxRetryTime time.Duration, gardenerDomain string, clusterName string, clusterRegion string, clusterProject string) error {
-	b := backoff.NewExponentialBackOff()
-	b.MaxElapsedTime = maxRetryTime - 1*time.Minute
-	b.InitialInterval = 120 * time.Second
-	b.MaxInterval = 75 * time.Second
-	b.Multiplier = 2
-	operation := func() error {
-		clusterResp, err := client.GetShootCluster(gardenerDomain, clusterName, clusterRegion, clusterProject)
-		if err != nil {
-			return backoff.Permanent(err)
-		}
-		lastState := clusterResp.Status.LastOperation.State
-		lastOperationType := clusterResp.Status.LastOperation.Type
-		if !((lastState == "Succeeded" && lastOperationType == "Create") || (lastState == "Succeeded" && lastOperationType == "Reconcile")) {
-
-			if (b.GetElapsedTime()+b.NextBackOff() >= 400*time.Second) && b.MaxInterval != 30*time.Second {
-				b.MaxInterval = 30 * time.Second
-				b.InitialInterval = 30 * time.Second
-				b.RandomizationFactor = 0
-				b.MaxElapsedTime -= b.GetElapsedTime()
-				b.Reset()
-			}
-			return errors.New("last operation is not finished yet")
-		}
-		return nil
+// waitForClusterIdle waits until the cluster has no operation in progress before a mutating request is sent.
+// It returns the last update time of the last operation, see clusterIdleWaiter.
+func (r *shootClusterResource) waitForClusterIdle(ctx context.Context, polling pollingSchedule, key clusterKey, diags *diag.Diagnostics) string {
+	lastUpdateTime, err := clusterIdleWaiter(r.client, ctx, polling, key)
+	if err != nil {
+		diags.AddError(
+			"API Error while waiting for ongoing cluster operation to finish",
+			fmt.Sprintf("... details ... %s", err),
+		)
 	}
-	return backoff.Retry(operation, backoff.WithContext(b, ctx))
+	return lastUpdateTime
 }
 
-func clusterReadyOperationWaiter(client *cleura.Client, ctx context.Context, maxRetryTime time.Duration, gardenerDomain string, clusterName string, clusterRegion string, clusterProject string) error {
-	b := backoff.NewExponentialBackOff()
-	b.MaxElapsedTime = maxRetryTime - 1*time.Minute
-	b.MaxInterval = 75 * time.Second
-	b.InitialInterval = 120 * time.Second
-	b.Multiplier = 2
-	operation := func() error {
-		clusterResp, err := client.GetShootCluster(gardenerDomain, clusterName, clusterRegion, clusterProject)
-		if err != nil {
-			return backoff.Permanent(err)
-		}
-		if len(clusterResp.Status.Conditions) < 1 {
-			return errors.New("cluster has no events yet")
-		}
-		for _, cond := range clusterResp.Status.Conditions {
-			if cond.Status != "True" {
-				if (b.GetElapsedTime()+b.NextBackOff() >= 400*time.Second) && b.MaxInterval != 30*time.Second {
-					b.MaxInterval = 30 * time.Second
-					b.InitialInterval = 30 * time.Second
-					b.RandomizationFactor = 0
-					b.MaxElapsedTime -= b.GetElapsedTime()
-					b.Reset()
-				}
-				return errors.New("cluster is not ready yet")
-			}
-		}
-		return nil
+// setHibernation hibernates or wakes up the cluster and waits for it. Nothing is requested if the cluster
+// already is in the requested state, e.g. after a hibernation schedule changed it.
+func (r *shootClusterResource) setHibernation(ctx context.Context, polling pollingSchedule, key clusterKey, hibernated bool, diags *diag.Diagnostics) {
+	since := r.waitForClusterIdle(ctx, polling, key, diags)
+	if diags.HasError() {
+		return
 	}
-	return backoff.Retry(operation, backoff.WithContext(b, ctx))
-
-}
 
-func deleteClusterOperationWaiter(client *cleura.Client, ctx context.Context, maxRetryTime time.Duration, gardenerDomain string, clusterName string, clusterRegion string, clusterProject string) error {
-	b := backoff.NewExponentialBackOff()
-	b.MaxElapsedTime = maxRetryTime - 1*time.Minute
-	b.MaxInterval = 75 * time.Second
-	b.InitialInterval = 120 * time.Second
-	b.Multiplier = 2
-	operation := func() error {
-
-		_, err := client.GetShootCluster(gardenerDomain, clusterName, clusterRegion, clusterProject)
-		if err != nil {
-			re, ok := err.(*cleura.RequestAPIError)
-			if ok {
-				if re.StatusCode == 404 {
-					return nil
-				}
-			}
-			return backoff.Permanent(err)
-		}
-		if (b.GetElapsedTime()+b.NextBackOff() >= 500*time.Second) && b.MaxInterval != 30*time.Second {
-			b.MaxInterval = 30 * time.Second
-			b.InitialInterval = 30 * time.Second
-			b.RandomizationFactor = 0
-			b.MaxElapsedTime -= b.GetElapsedTime()
-			b.Reset()
-		}
+	shoot, err := r.client.GetShootCluster(ctx, key.gardenerDomain, key.name, key.region, key.project)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Shoot cluster",
+			"Could not read Shoot cluster name "+key.name+": "+err.Error(),
+		)
+		return
+	}
+	if shoot.Status.Hibernated == hibernated && shoot.Spec.Hibernation.Enabled == hibernated {
+		return
+	}
 
-		return errors.New("cluster is not deleted yet")
+	if hibernated {
+		err = r.client.HibernateCluster(ctx, key.gardenerDomain, key.region, key.project, key.name)
+	} else {
+		err = r.client.WakeUpCluster(ctx, key.gardenerDomain, key.region, key.project, key.name)
+	}
+	if err != nil {
+		diags.AddError(
+			"Error changing shoot cluster hibernation",
+			"Could not hibernate or wake up cluster, unexpected error: "+err.Error(),
+		)
+		return
 	}
-	return backoff.Retry(operation, backoff.WithContext(b, ctx))
 
+	err = clusterHibernationWaiter(r.client, ctx, polling, key, hibernated, since)
+	if err != nil {
+		diags.AddError(
+			"API Error while waiting for cluster hibernation",
+			fmt.Sprintf("... details ... %s", err),
+		)
+	}
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -2180,7 +2775,7 @@ func (r *shootClusterResource) Read(ctx context.Context, req resource.ReadReques
 	tflog.Debug(ctx, "XXX_READ")
 
 	// Get current state
-	var state shootClusterResourceModelV2
+	var state shootClusterResourceModelV3
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -2188,7 +2783,7 @@ func (r *shootClusterResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	// Get refreshed shoot cluster from cleura
-	shootResponse, err := r.client.GetShootCluster(state.GardenerDomain.ValueString(), state.Name.ValueString(), state.Region.ValueString(), state.Project.ValueString())
+	shootResponse, err := r.client.GetShootClusterDetails(ctx, state.GardenerDomain.ValueString(), state.Name.ValueString(), state.Region.ValueString(), state.Project.ValueString())
 	if err != nil {
 		re, ok := err.(*cleura.RequestAPIError)
 		if ok {
@@ -2207,57 +2802,16 @@ func (r *shootClusterResource) Read(ctx context.Context, req resource.ReadReques
 	}
 	tflog.Debug(ctx, fmt.Sprintf("shootResponse: %+v", shootResponse))
 
-	haEnabled := false
-	if shootResponse.Spec.ControlPlane != (cleura.ControlPlaneDetails{}) {
-		haEnabled = true
-	}
-
-	state.HaControlPlane = types.BoolValue(haEnabled)
-	state.Name = types.StringValue(shootResponse.Metadata.Name)
-	state.UID = types.StringValue(shootResponse.Metadata.UID)
-	state.Hibernated = types.BoolValue(shootResponse.Status.Hibernated)
-	state.K8sVersion = types.StringValue(shootResponse.Spec.Kubernetes.Version)
-
-	// Set computed network field
-	state.ProviderDetails.NetworkId = types.StringValue(shootResponse.Spec.Provider.InfrastructureConfig.Networks.Id)
-	state.ProviderDetails.RouterId = types.StringValue(shootResponse.Spec.Provider.InfrastructureConfig.Networks.Router.Id)
-	state.ProviderDetails.WorkerCidr = types.StringValue(shootResponse.Spec.Provider.InfrastructureConfig.Networks.WorkersCIDR)
-
-	var workerGroups []attr.Value
-	for _, group := range state.ProviderDetails.WorkerGroups.Elements() {
-		objVal, diags := types.ObjectValueFrom(ctx, workerGroupModelAttrTypesV1(), group)
-		resp.Diagnostics.Append(diags...)
-		workerGroups = append(workerGroups, objVal)
-	}
-
-	state.ProviderDetails.WorkerGroups, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: workerGroupModelAttrTypesV1()}, workerGroups)
-	resp.Diagnostics.Append(diags...)
+	// Worker groups are refreshed from the API, keeping the attributes only known to the state
+	managed := attrValuesToWorkerGroupModelSlice(state.ProviderDetails.WorkerGroups.Elements(), &resp.Diagnostics)
+	refreshShootClusterModel(ctx, &state, shootResponse, managed, state.IgnoreUnmanaged.ValueBool(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Hibschedules current state: %v", state.HibernationSchedules))
-	var hibSchedules []hibernationScheduleModel
-
-	for _, schedule := range shootResponse.Spec.Hibernation.HibernationResponseSchedules {
-		hibSchedules = append(hibSchedules, hibernationScheduleModel{
-			Start: types.StringValue(schedule.Start),
-			End:   types.StringValue(schedule.End),
-		})
-	}
-	state.HibernationSchedules = hibSchedules
-	tflog.Debug(ctx, fmt.Sprintf("Hibschedules after state: %v", state.HibernationSchedules))
-
-	// Write existing maintenance configuration to state
-	state.Maintenance, diags = types.ObjectValueFrom(ctx, maintenanceAttrTypesV0(), maintenanceModel{
-		AutoUpdateKubernetes:   types.BoolValue(shootResponse.Spec.Maintenance.AutoUpdate.KubernetesVersion),
-		AutoUpdateMachineImage: types.BoolValue(shootResponse.Spec.Maintenance.AutoUpdate.MachineImageVersion),
-		TimeWindowBegin:        types.StringValue(shootResponse.Spec.Maintenance.TimeWindow.Begin),
-		TimeWindowEnd:          types.StringValue(shootResponse.Spec.Maintenance.TimeWindow.End),
-	})
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	// Clusters created before deletion protection was added use the provider default
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(r.defaults.deletionProtection)
 	}
 
 	// Set refreshed state
@@ -2271,8 +2825,8 @@ func (r *shootClusterResource) Read(ctx context.Context, req resource.ReadReques
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *shootClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Debug(ctx, "XXX_UPDATE")
-	var plan shootClusterResourceModelV2
-	var currentState shootClusterResourceModelV2
+	var plan shootClusterResourceModelV3
+	var currentState shootClusterResourceModelV3
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -2289,14 +2843,46 @@ func (r *shootClusterResource) Update(ctx context.Context, req resource.UpdateRe
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	polling := newPollingSchedule(plan.Polling, r.defaults.polling, path.Root("polling"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := clusterKey{
+		gardenerDomain: plan.GardenerDomain.ValueString(),
+		region:         plan.Region.ValueString(),
+		project:        plan.Project.ValueString(),
+		name:           plan.Name.ValueString(),
+	}
+	unlock, err := lockCluster(ctx, key)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating shoot cluster",
+			"Could not acquire the shoot cluster operation lock: "+err.Error(),
+		)
+		return
+	}
+	defer unlock()
+
+	// Only changes of hibernation_enabled are applied, so hibernation schedules changing the state of the
+	// cluster in between do not cause a diff. The cluster is woken up before and hibernated after other changes.
+	hibernationChanged := !plan.HibernationEnabled.IsNull() && !plan.HibernationEnabled.Equal(currentState.HibernationEnabled)
+	if hibernationChanged && !plan.HibernationEnabled.ValueBool() {
+		r.setHibernation(ctx, polling, key, false, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	if !reflect.DeepEqual(plan.HibernationSchedules, currentState.HibernationSchedules) || !plan.Maintenance.Equal(currentState.Maintenance) || !reflect.DeepEqual(plan.K8sVersion, currentState.K8sVersion) || !reflect.DeepEqual(plan.HaControlPlane, currentState.HaControlPlane) {
 		tflog.Debug(ctx, "Hibernation schedules or K8s version changed")
 
-		hibernationSchedules := []cleura.HibernationSchedule{}
+		hibernationSchedules := []hibernationScheduleRequest{}
 		for _, schedule := range plan.HibernationSchedules {
-			hibernationSchedules = append(hibernationSchedules, cleura.HibernationSchedule{
-				Start: schedule.Start.ValueString(),
-				End:   schedule.End.ValueString(),
+			hibernationSchedules = append(hibernationSchedules, hibernationScheduleRequest{
+				Start:    schedule.Start.ValueString(),
+				End:      schedule.End.ValueString(),
+				Location: schedule.Location.ValueString(),
 			},
 			)
 		}
@@ -2307,23 +2893,25 @@ func (r *shootClusterResource) Update(ctx context.Context, req resource.UpdateRe
 			return
 		}
 
-		clusterUpdateRequest := cleura.ShootClusterRequest{
-			Shoot: cleura.ShootClusterRequestConfig{
-				Provider: &cleura.ProviderDetailsRequest{},
-				KubernetesVersion: &cleura.K8sVersion{
-					Version: plan.K8sVersion.ValueString(),
-				},
-				Hibernation: &cleura.HibernationSchedules{
-					HibernationSchedules: hibernationSchedules,
+		clusterUpdateRequest := shootClusterRequest{
+			Shoot: shootClusterRequestConfig{
+				Hibernation: &hibernationSchedulesRequest{
+					Schedules: hibernationSchedules,
 				},
-				Maintenance: &cleura.MaintenanceDetails{
-					AutoUpdate: &cleura.AutoUpdateDetails{
-						KubernetesVersion:   maintenance.AutoUpdateKubernetes.ValueBool(),
-						MachineImageVersion: maintenance.AutoUpdateMachineImage.ValueBool(),
+				ShootClusterRequestConfig: cleura.ShootClusterRequestConfig{
+					Provider: &cleura.ProviderDetailsRequest{},
+					KubernetesVersion: &cleura.K8sVersion{
+						Version: plan.K8sVersion.ValueString(),
 					},
-					TimeWindow: &cleura.TimeWindowDetails{
-						Begin: maintenance.TimeWindowBegin.ValueString(),
-						End:   maintenance.TimeWindowEnd.ValueString(),
+					Maintenance: &cleura.MaintenanceDetails{
+						AutoUpdate: &cleura.AutoUpdateDetails{
+							KubernetesVersion:   maintenance.AutoUpdateKubernetes.ValueBool(),
+							MachineImageVersion: maintenance.AutoUpdateMachineImage.ValueBool(),
+						},
+						TimeWindow: &cleura.TimeWindowDetails{
+							Begin: maintenance.TimeWindowBegin.ValueString(),
+							End:   maintenance.TimeWindowEnd.ValueString(),
+						},
 					},
 				},
 			},
@@ -2334,7 +2922,11 @@ func (r *shootClusterResource) Update(ctx context.Context, req resource.UpdateRe
 			clusterUpdateRequest.Shoot.EnableHaControlPlane = plan.HaControlPlane.ValueBool()
 		}
 
-		_, err := r.client.UpdateShootCluster(plan.GardenerDomain.ValueString(), plan.Region.ValueString(), plan.Project.ValueString(), plan.Name.ValueString(), clusterUpdateRequest)
+		since := r.waitForClusterIdle(ctx, polling, key, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		_, err := r.client.UpdateShootCluster(ctx, plan.GardenerDomain.ValueString(), plan.Region.ValueString(), plan.Project.ValueString(), plan.Name.ValueString(), clusterUpdateRequest)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error updating shoot cluster",
@@ -2342,7 +2934,7 @@ func (r *shootClusterResource) Update(ctx context.Context, req resource.UpdateRe
 			)
 			return
 		}
-		err = clusterReconcileWaiter(r.client, ctx, createTimeout, plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString())
+		err = clusterReconcileWaiter(r.client, ctx, polling, key, since)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"API Error while waiting for cluster to become ready (modify)",
@@ -2382,7 +2974,11 @@ func (r *shootClusterResource) Update(ctx context.Context, req resource.UpdateRe
 			return
 		}
 
-		_, err := r.client.UpdateWorkerGroup(plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString(), wg.WorkerGroupName.ValueString(), cleura.WorkerGroupRequest{Worker: workerGroupRequest})
+		since := r.waitForClusterIdle(ctx, polling, key, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		_, err := r.client.UpdateWorkerGroup(ctx, plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString(), wg.WorkerGroupName.ValueString(), cleura.WorkerGroupRequest{Worker: workerGroupRequest})
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"API Error Updating Worker Group",
@@ -2391,7 +2987,7 @@ func (r *shootClusterResource) Update(ctx context.Context, req resource.UpdateRe
 			return
 		}
 
-		err = clusterReconcileWaiter(r.client, ctx, createTimeout, plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString())
+		err = clusterReconcileWaiter(r.client, ctx, polling, key, since)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"API Error while waiting for cluster to become ready (modify)",
@@ -2407,7 +3003,11 @@ func (r *shootClusterResource) Update(ctx context.Context, req resource.UpdateRe
 			return
 		}
 
-		_, err := r.client.AddWorkerGroup(plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString(), cleura.WorkerGroupRequest{Worker: workerGroupRequest})
+		since := r.waitForClusterIdle(ctx, polling, key, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		_, err := r.client.AddWorkerGroup(ctx, plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString(), cleura.WorkerGroupRequest{Worker: workerGroupRequest})
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"API Error Adding Worker Group",
@@ -2416,7 +3016,7 @@ func (r *shootClusterResource) Update(ctx context.Context, req resource.UpdateRe
 			return
 		}
 
-		err = clusterReconcileWaiter(r.client, ctx, createTimeout, plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString())
+		err = clusterReconcileWaiter(r.client, ctx, polling, key, since)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"API Error while waiting for cluster to become ready (modify)",
@@ -2427,7 +3027,11 @@ func (r *shootClusterResource) Update(ctx context.Context, req resource.UpdateRe
 
 	}
 	for _, wg := range wgDelete {
-		_, err := r.client.DeleteWorkerGroup(plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString(), wg.WorkerGroupName.ValueString())
+		since := r.waitForClusterIdle(ctx, polling, key, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		_, err := r.client.DeleteWorkerGroup(ctx, plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString(), wg.WorkerGroupName.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"API Error Deleting Worker Group",
@@ -2436,7 +3040,7 @@ func (r *shootClusterResource) Update(ctx context.Context, req resource.UpdateRe
 			return
 		}
 
-		err = clusterReconcileWaiter(r.client, ctx, createTimeout, plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString())
+		err = clusterReconcileWaiter(r.client, ctx, polling, key, since)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"API Error while waiting for cluster to become ready (modify)",
@@ -2446,7 +3050,14 @@ func (r *shootClusterResource) Update(ctx context.Context, req resource.UpdateRe
 		}
 	}
 
-	clusterUpdateResp, err := r.client.GetShootCluster(plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString())
+	if hibernationChanged && plan.HibernationEnabled.ValueBool() {
+		r.setHibernation(ctx, polling, key, true, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	clusterUpdateResp, err := r.client.GetShootClusterDetails(ctx, plan.GardenerDomain.ValueString(), plan.Name.ValueString(), plan.Region.ValueString(), plan.Project.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 
@@ -2457,43 +3068,14 @@ func (r *shootClusterResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 	tflog.Debug(ctx, fmt.Sprintf("response after all: %+v, ", clusterUpdateResp))
 
-	haEnabled := false
-	if clusterUpdateResp.Spec.ControlPlane != (cleura.ControlPlaneDetails{}) {
-		haEnabled = true
-	}
-	plan.HaControlPlane = types.BoolValue(haEnabled)
-
-	plan.UID = currentState.UID // types.StringValue(clusterUpdateResp.Metadata.UID)
-	plan.Hibernated = types.BoolValue(clusterUpdateResp.Status.Hibernated)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
-
-	var workerGroups []attr.Value
-
-	for _, worker := range clusterUpdateResp.Spec.Provider.Workers {
-		obj, diags := cleuraWorkerToObjectValue(ctx, worker)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		workerGroups = append(workerGroups, obj)
-	}
-
-	plan.ProviderDetails.WorkerGroups, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: workerGroupModelAttrTypesV1()}, workerGroups)
-	resp.Diagnostics.Append(diags...)
+	// Only the planned worker groups are stored, as the applied state must match the plan. Unmanaged worker
+	// groups show up as drift on the next Read.
+	refreshShootClusterModel(ctx, &plan, clusterUpdateResp, attrValuesToWorkerGroupModelSlice(plannedWorkerGroups, &resp.Diagnostics), true, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var hibSchedules []hibernationScheduleModel // nil
-
-	for _, schedule := range clusterUpdateResp.Spec.Hibernation.HibernationResponseSchedules {
-		hibSchedules = append(hibSchedules, hibernationScheduleModel{
-			Start: types.StringValue(schedule.Start),
-			End:   types.StringValue(schedule.End),
-		})
-	}
-	plan.HibernationSchedules = hibSchedules // set nil if no schedule present, slice with schedules if present
-
 	// Setting the final state
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -2506,21 +3088,53 @@ func (r *shootClusterResource) Update(ctx context.Context, req resource.UpdateRe
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *shootClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "XXX_DELETE")
-	var state shootClusterResourceModelV2
+	var state shootClusterResourceModelV3
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if state.DeletionProtection.ValueBool() {
+		addDeletionProtectionError(&resp.Diagnostics, state.Name, "destroying it")
+		return
+	}
 	// Set default delete timeout if not set in configuration
 	createTimeout, diags := state.Timeouts.Delete(ctx, 45*time.Minute)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	polling := newPollingSchedule(state.Polling, r.defaults.polling, path.Root("polling"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := clusterKey{
+		gardenerDomain: state.GardenerDomain.ValueString(),
+		region:         state.Region.ValueString(),
+		project:        state.Project.ValueString(),
+		name:           state.Name.ValueString(),
+	}
+	unlock, err := lockCluster(ctx, key)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Shoot Cluster",
+			"Could not acquire the shoot cluster operation lock: "+err.Error(),
+		)
+		return
+	}
+	defer unlock()
+
+	since := r.waitForClusterIdle(ctx, polling, key, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Delete existing cluster
-	_, err := r.client.DeleteShootCluster(state.GardenerDomain.ValueString(), state.Name.ValueString(), state.Region.ValueString(), state.Project.ValueString())
+	_, err = r.client.DeleteShootCluster(ctx, state.GardenerDomain.ValueString(), state.Name.ValueString(), state.Region.ValueString(), state.Project.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Shoot Cluster",
@@ -2529,7 +3143,7 @@ func (r *shootClusterResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 	// Wait until API responds with 404
-	err = deleteClusterOperationWaiter(r.client, ctx, createTimeout, state.GardenerDomain.ValueString(), state.Name.ValueString(), state.Region.ValueString(), state.Project.ValueString())
+	err = deleteClusterWaiter(r.client, ctx, polling, key, since)
 	if err != nil {
 		resp.Diagnostics.AddError(
 
@@ -2573,7 +3187,7 @@ func getCreateModifyDeleteWorkgroups(wgsPlan []workerGroupModelV1, wgsState []wo
 
 func (r *shootClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	idParts := strings.Split(req.ID, ",")
-	var state shootClusterResourceModelV2
+	var state shootClusterResourceModelV3
 	tflog.Debug(ctx, fmt.Sprintf("idparts: %v", idParts))
 	if len(idParts) != 4 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" || idParts[3] == "" {
 		resp.Diagnostics.AddError(
@@ -2586,11 +3200,12 @@ func (r *shootClusterResource) ImportState(ctx context.Context, req resource.Imp
 	state.Name = types.StringValue(idParts[1])
 	state.Region = types.StringValue(idParts[2])
 	state.Project = types.StringValue(idParts[3])
+	state.DeletionProtection = types.BoolValue(r.defaults.deletionProtection)
 
 	// Get refreshed shoot cluster from cleura
 
-	shootResponse, err := r.client.GetShootCluster(state.GardenerDomain.ValueString(), state.Name.ValueString(), state.Region.ValueString(), state.Project.ValueString())
-	// shootResponse, err := r.client.GetShootCluster(idParts[0], idParts[1], idParts[2], idParts[3])
+	shootResponse, err := r.client.GetShootClusterDetails(ctx, state.GardenerDomain.ValueString(), state.Name.ValueString(), state.Region.ValueString(), state.Project.ValueString())
+	// shootResponse, err := r.client.GetShootCluster(ctx, idParts[0], idParts[1], idParts[2], idParts[3])
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Shoot cluster",
@@ -2600,70 +3215,12 @@ func (r *shootClusterResource) ImportState(ctx context.Context, req resource.Imp
 	}
 	tflog.Debug(ctx, fmt.Sprintf("shootResponse: %+v", shootResponse))
 
-	haEnabled := false
-	if shootResponse.Spec.ControlPlane != (cleura.ControlPlaneDetails{}) {
-		haEnabled = true
-	}
-
-	state.UID = types.StringValue(shootResponse.Metadata.UID)
-	state.HaControlPlane = types.BoolValue(haEnabled)
-	state.Hibernated = types.BoolValue(shootResponse.Status.Hibernated)
-	state.K8sVersion = types.StringValue(shootResponse.Spec.Kubernetes.Version)
-	state.ProviderDetails.FloatingPoolName = types.StringValue(shootResponse.Spec.Provider.InfrastructureConfig.FloatingPoolName)
-	state.ProviderDetails.NetworkId = types.StringValue(shootResponse.Spec.Provider.InfrastructureConfig.Networks.Id)
-	state.ProviderDetails.RouterId = types.StringValue(shootResponse.Spec.Provider.InfrastructureConfig.Networks.Router.Id)
-	state.ProviderDetails.WorkerCidr = types.StringValue(shootResponse.Spec.Provider.InfrastructureConfig.Networks.WorkersCIDR)
-
-	// make an attr.Value slice and fill with existing workers
-	var workerGroups []attr.Value
-	for _, group := range state.ProviderDetails.WorkerGroups.Elements() {
-		objVal, diags := types.ObjectValueFrom(ctx, workerGroupModelAttrTypesV1(), group)
-		resp.Diagnostics.Append(diags...)
-		workerGroups = append(workerGroups, objVal)
-	}
-
-	// add imported worker groups to state
-	for _, worker := range shootResponse.Spec.Provider.Workers {
-		obj, diags := cleuraWorkerToObjectValue(ctx, worker)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		workerGroups = append(workerGroups, obj)
-	}
-
-	var diags diag.Diagnostics
-
-	// Write existing maintenance configuration to state
-	state.Maintenance, diags = types.ObjectValueFrom(ctx, maintenanceAttrTypesV0(), maintenanceModel{
-		AutoUpdateKubernetes:   types.BoolValue(shootResponse.Spec.Maintenance.AutoUpdate.KubernetesVersion),
-		AutoUpdateMachineImage: types.BoolValue(shootResponse.Spec.Maintenance.AutoUpdate.MachineImageVersion),
-		TimeWindowBegin:        types.StringValue(shootResponse.Spec.Maintenance.TimeWindow.Begin),
-		TimeWindowEnd:          types.StringValue(shootResponse.Spec.Maintenance.TimeWindow.End),
-	})
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	state.ProviderDetails.WorkerGroups, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: workerGroupModelAttrTypesV1()}, workerGroups)
-	resp.Diagnostics.Append(diags...)
+	// All worker groups of the cluster are imported
+	refreshShootClusterModel(ctx, &state, shootResponse, nil, false, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Hibschedules current state: %v", state.HibernationSchedules))
-	var hibSchedules []hibernationScheduleModel
-
-	for _, schedule := range shootResponse.Spec.Hibernation.HibernationResponseSchedules {
-		hibSchedules = append(hibSchedules, hibernationScheduleModel{
-			Start: types.StringValue(schedule.Start),
-			End:   types.StringValue(schedule.End),
-		})
-	}
-	state.HibernationSchedules = hibSchedules
-	tflog.Debug(ctx, fmt.Sprintf("Hibschedules after state: %v", state.HibernationSchedules))
-
 	state.Timeouts = timeouts.Value{
 		Object: types.ObjectNull(map[string]attr.Type{
 			"create": types.StringType,
@@ -2673,7 +3230,7 @@ func (r *shootClusterResource) ImportState(ctx context.Context, req resource.Imp
 	}
 
 	// Set refreshed state
-	diags = resp.State.Set(ctx, &state)
+	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -0,0 +1,303 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testShootClusterSchema returns the current schema of cleura_shoot_cluster.
+func testShootClusterSchema(t *testing.T) schema.Schema {
+	t.Helper()
+	var resp resource.SchemaResponse
+	NewShootClusterResource().Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", resp.Diagnostics)
+	}
+	return resp.Schema
+}
+
+// testObjectValue returns an object of the given type with the given attribute values. All other attributes are null.
+func testObjectValue(objectType tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+			continue
+		}
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
+
+func TestShootClusterPlanUnknownComputedLists(t *testing.T) {
+	ctx := context.Background()
+	s := testShootClusterSchema(t)
+	objectType, ok := s.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected object schema type, got %T", s.Type().TerraformType(ctx))
+	}
+	providerDetailsType, ok := objectType.AttributeTypes["provider_details"].(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected object provider_details type, got %T", objectType.AttributeTypes["provider_details"])
+	}
+
+	// Computed attributes without a prior value are unknown in the plan of a create or update.
+	plan := tfsdk.Plan{
+		Schema: s,
+		Raw: testObjectValue(objectType, map[string]tftypes.Value{
+			"name":                 tftypes.NewValue(tftypes.String, "test"),
+			"provider_details":     testObjectValue(providerDetailsType, nil),
+			"conditions":           tftypes.NewValue(objectType.AttributeTypes["conditions"], tftypes.UnknownValue),
+			"advertised_addresses": tftypes.NewValue(objectType.AttributeTypes["advertised_addresses"], tftypes.UnknownValue),
+		}),
+	}
+
+	var model shootClusterResourceModelV3
+	if diags := plan.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics reading the plan: %v", diags)
+	}
+	if !model.Conditions.IsUnknown() {
+		t.Errorf("expected unknown conditions, got %s", model.Conditions)
+	}
+	if !model.AdvertisedAddresses.IsUnknown() {
+		t.Errorf("expected unknown advertised_addresses, got %s", model.AdvertisedAddresses)
+	}
+
+	if diags := plan.Set(ctx, model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics setting the plan: %v", diags)
+	}
+}
+
+// testUpgradeShootClusterState upgrades the raw state of the given version with the upgrader of that version.
+func testUpgradeShootClusterState(t *testing.T, version int64, rawState string) shootClusterResourceModelV3 {
+	t.Helper()
+	ctx := context.Background()
+	r, ok := NewShootClusterResource().(resource.ResourceWithUpgradeState)
+	if !ok {
+		t.Fatal("expected cleura_shoot_cluster to implement state upgrades")
+	}
+	upgrader, ok := r.UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("expected state upgrader for version %d", version)
+	}
+	if upgrader.PriorSchema.Version != version {
+		t.Fatalf("expected prior schema version %d, got %d", version, upgrader.PriorSchema.Version)
+	}
+
+	// Unmarshalled as the framework does before calling the upgrader.
+	prior, err := (&tfprotov6.RawState{JSON: []byte(rawState)}).UnmarshalWithOpts(
+		upgrader.PriorSchema.Type().TerraformType(ctx),
+		tfprotov6.UnmarshalOpts{ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error reading the raw state: %s", err)
+	}
+	req := resource.UpgradeStateRequest{
+		State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: prior},
+	}
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: testShootClusterSchema(t)},
+	}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected upgrade diagnostics: %v", resp.Diagnostics)
+	}
+
+	var model shootClusterResourceModelV3
+	if diags := resp.State.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics reading the upgraded state: %v", diags)
+	}
+	return model
+}
+
+func TestShootClusterUpgradeStateV3(t *testing.T) {
+	model := testUpgradeShootClusterState(t, 3, `{
+		"name": "test",
+		"project": "project-id",
+		"region": "sto2",
+		"kubernetes_version": "1.30.2",
+		"uid": "abc123",
+		"hibernation_schedules": [{"start": "00 18 * * 1,2,3,4,5", "end": "00 08 * * 1,2,3,4,5"}],
+		"provider_details": {
+			"floating_pool_name": "ext-net",
+			"worker_groups": [
+				{"worker_group_name": "wr001", "machine_type": "b.2c4gb", "min_nodes": 1, "max_nodes": 3, "image_name": "gardenlinux", "image_version": "1443.2.0", "zones": ["nova"]},
+				{"worker_group_name": "wr002", "machine_type": "b.4c8gb", "min_nodes": 2, "max_nodes": 4, "image_name": "gardenlinux", "image_version": "1443.2.0"}
+			]
+		}
+	}`)
+
+	if model.Name.ValueString() != "test" || model.UID.ValueString() != "abc123" || model.K8sVersion.ValueString() != "1.30.2" {
+		t.Errorf("expected name, uid and kubernetes_version to be kept, got %s, %s and %s", model.Name, model.UID, model.K8sVersion)
+	}
+	workerGroups := model.ProviderDetails.WorkerGroups.Elements()
+	if len(workerGroups) != 2 {
+		t.Fatalf("expected 2 worker groups, got %d", len(workerGroups))
+	}
+	for _, element := range workerGroups {
+		workerGroup, ok := element.(types.Object)
+		if !ok {
+			t.Fatalf("expected worker group object, got %T", element)
+		}
+		for _, name := range []string{"image_version_constraint", "image_version_classification"} {
+			if value := workerGroup.Attributes()[name]; !value.IsNull() {
+				t.Errorf("expected null %s, got %s", name, value)
+			}
+		}
+	}
+	if len(model.HibernationSchedules) != 1 || !model.HibernationSchedules[0].Location.IsNull() {
+		t.Errorf("expected a hibernation schedule with null location, got %+v", model.HibernationSchedules)
+	}
+	if !model.Conditions.IsNull() || !model.AdvertisedAddresses.IsNull() {
+		t.Errorf("expected null conditions and advertised_addresses, got %s and %s", model.Conditions, model.AdvertisedAddresses)
+	}
+}
+
+func TestShootClusterUpgradeStateV4(t *testing.T) {
+	model := testUpgradeShootClusterState(t, 4, `{
+		"name": "test",
+		"project": "project-id",
+		"region": "sto2",
+		"kubernetes_version": "1.30.2",
+		"kubernetes_version_constraint": "~> 1.30.0",
+		"kubernetes_version_classification": "supported",
+		"deletion_protection": true,
+		"hibernation_schedules": [{"start": "00 18 * * 1,2,3,4,5", "end": "00 08 * * 1,2,3,4,5", "location": "Europe/Stockholm"}],
+		"provider_details": {
+			"worker_groups": [
+				{"worker_group_name": "wr001", "machine_type": "b.2c4gb", "min_nodes": 1, "max_nodes": 3, "image_version": "1443.2.0", "image_version_constraint": ">= 1443.2", "image_version_classification": "supported"}
+			]
+		}
+	}`)
+
+	if model.K8sVersionConstraint.ValueString() != "~> 1.30.0" || model.K8sClassification.ValueString() != "supported" {
+		t.Errorf("expected kubernetes version constraint and classification to be kept, got %s and %s", model.K8sVersionConstraint, model.K8sClassification)
+	}
+	if !model.DeletionProtection.ValueBool() {
+		t.Error("expected deletion_protection to be kept")
+	}
+	if len(model.HibernationSchedules) != 1 || model.HibernationSchedules[0].Location.ValueString() != "Europe/Stockholm" {
+		t.Errorf("expected hibernation schedule location to be kept, got %+v", model.HibernationSchedules)
+	}
+	workerGroups := model.ProviderDetails.WorkerGroups.Elements()
+	if len(workerGroups) != 1 {
+		t.Fatalf("expected 1 worker group, got %d", len(workerGroups))
+	}
+	workerGroup, ok := workerGroups[0].(types.Object)
+	if !ok {
+		t.Fatalf("expected worker group object, got %T", workerGroups[0])
+	}
+	if constraint := workerGroup.Attributes()["image_version_constraint"]; constraint.String() != `">= 1443.2"` {
+		t.Errorf("expected image_version_constraint to be kept, got %s", constraint)
+	}
+	if !model.Conditions.IsNull() || !model.AdvertisedAddresses.IsNull() {
+		t.Errorf("expected null conditions and advertised_addresses, got %s and %s", model.Conditions, model.AdvertisedAddresses)
+	}
+}
+
+func TestRefreshShootClusterModelWorkerGroups(t *testing.T) {
+	shoot := &shootClusterDetails{ShootClusterResponse: &cleura.ShootClusterResponse{}}
+	shoot.Spec.Provider.InfrastructureConfig.Networks = &cleura.WorkerNetwork{}
+	shoot.Spec.Maintenance = cleura.MaintenanceDetails{AutoUpdate: &cleura.AutoUpdateDetails{}, TimeWindow: &cleura.TimeWindowDetails{}}
+	shoot.Spec.Provider.Workers = []cleura.WorkerUpdateResponse{{Name: "wr001"}, {Name: "teamb"}}
+	managed := []workerGroupModelV1{{
+		WorkerGroupName:        types.StringValue("wr001"),
+		ImageVersionConstraint: types.StringValue(">= 1443.2"),
+		ImageClassification:    types.StringValue("supported"),
+	}}
+
+	tests := []struct {
+		name            string
+		ignoreUnmanaged bool
+		expected        []string
+	}{
+		{name: "unmanaged worker groups are drift", expected: []string{"teamb", "wr001"}},
+		{name: "unmanaged worker groups are ignored", ignoreUnmanaged: true, expected: []string{"wr001"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var model shootClusterResourceModelV3
+			var diags diag.Diagnostics
+			refreshShootClusterModel(context.Background(), &model, shoot, managed, tt.ignoreUnmanaged, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			var names []string
+			for _, wg := range attrValuesToWorkerGroupModelSlice(model.ProviderDetails.WorkerGroups.Elements(), &diags) {
+				names = append(names, wg.WorkerGroupName.ValueString())
+				if wg.WorkerGroupName.ValueString() == "wr001" && wg.ImageVersionConstraint.ValueString() != ">= 1443.2" {
+					t.Errorf("expected image_version_constraint of the managed worker group to be kept, got %s", wg.ImageVersionConstraint)
+				}
+			}
+			slices.Sort(names)
+			if !slices.Equal(names, tt.expected) {
+				t.Errorf("expected worker groups %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestProtectedReplaceString(t *testing.T) {
+	ctx := context.Background()
+	s := testShootClusterSchema(t)
+	objectType, ok := s.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected object schema type, got %T", s.Type().TerraformType(ctx))
+	}
+	state := func(deletionProtection bool) tfsdk.State {
+		return tfsdk.State{Schema: s, Raw: testObjectValue(objectType, map[string]tftypes.Value{
+			"name":                tftypes.NewValue(tftypes.String, "test"),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, deletionProtection),
+		})}
+	}
+
+	tests := []struct {
+		name            string
+		state           tfsdk.State
+		planValue       string
+		requiresReplace bool
+		expectError     bool
+	}{
+		{name: "unprotected", state: state(false), planValue: "renamed", requiresReplace: true},
+		{name: "protected", state: state(true), planValue: "renamed", requiresReplace: true, expectError: true},
+		{name: "protected unchanged", state: state(true), planValue: "test"},
+		{name: "create", state: tfsdk.State{Schema: s, Raw: tftypes.NewValue(objectType, nil)}, planValue: "test"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateValue := types.StringNull()
+			if !tt.state.Raw.IsNull() {
+				stateValue = types.StringValue("test")
+			}
+			req := planmodifier.StringRequest{
+				Path:       path.Root("name"),
+				State:      tt.state,
+				Plan:       tfsdk.Plan{Schema: s, Raw: testObjectValue(objectType, nil)},
+				StateValue: stateValue,
+				PlanValue:  types.StringValue(tt.planValue),
+			}
+			resp := planmodifier.StringResponse{PlanValue: req.PlanValue}
+			protectedReplaceString(stringplanmodifier.RequiresReplace()).PlanModifyString(ctx, req, &resp)
+			if resp.RequiresReplace != tt.requiresReplace {
+				t.Errorf("expected requires replace %t, got %t", tt.requiresReplace, resp.RequiresReplace)
+			}
+			if resp.Diagnostics.HasError() != tt.expectError {
+				t.Fatalf("expected error %t, got %v", tt.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -1,11 +1,14 @@
 package provider
 
 import (
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccShootResource(t *testing.T) {
@@ -27,27 +30,39 @@ func TestAccShootResource(t *testing.T) {
 					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.#", "1"),
 					// Verify Kubernetes version
 					// resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "kubernetes_version", "1.32.4"),
-					// Verify first worker group in list
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.image_name", "gardenlinux"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.machine_type", "b.2c4gb"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.max_nodes", "2"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.min_nodes", "1"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.worker_group_name", "tstwg"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.worker_node_volume_size", "50Gi"),
+					// Verify the worker group
+					resource.TestCheckTypeSetElemNestedAttrs("cleura_shoot_cluster.test", "provider_details.worker_groups.*", map[string]string{
+						"worker_group_name":       "tstwg",
+						"image_name":              "gardenlinux",
+						"machine_type":            "b.2c4gb",
+						"max_nodes":               "2",
+						"min_nodes":               "1",
+						"worker_node_volume_size": "50Gi",
+					}),
 
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("cleura_shoot_cluster.test", "uid"),
 					resource.TestCheckResourceAttrSet("cleura_shoot_cluster.test", "last_updated"),
 					resource.TestCheckResourceAttrSet("cleura_shoot_cluster.test", "hibernated"),
+					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "last_operation.state", "Succeeded"),
+					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "deletion_protection", "false"),
+					resource.TestCheckResourceAttrSet("cleura_shoot_cluster.test", "advertised_addresses.0.url"),
+					resource.TestCheckTypeSetElemNestedAttrs("cleura_shoot_cluster.test", "conditions.*", map[string]string{
+						"type":   "APIServerAvailable",
+						"status": "True",
+					}),
 
 					// Verify annotations, labels, taints and zones are set.
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.annotations.%", "2"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.annotations.test", "123"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.labels.%", "1"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.labels.tftestlabel", "def"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.taints.#", "1"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.zones.#", "1"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.zones.0", "nova"),
+					resource.TestCheckTypeSetElemNestedAttrs("cleura_shoot_cluster.test", "provider_details.worker_groups.*", map[string]string{
+						"worker_group_name":  "tstwg",
+						"annotations.%":      "2",
+						"annotations.test":   "123",
+						"labels.%":           "1",
+						"labels.tftestlabel": "def",
+						"taints.#":           "1",
+						"zones.#":            "1",
+						"zones.0":            "nova",
+					}),
 
 					// Verify maintenance configuration
 					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "maintenance.auto_update_kubernetes", "true"),     // Default value, not set in config
@@ -62,22 +77,23 @@ func TestAccShootResource(t *testing.T) {
 				ConfigVariables: varsTest,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					// Verify max nodes has changed
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.max_nodes", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs("cleura_shoot_cluster.test", "provider_details.worker_groups.*", map[string]string{
+						"worker_group_name": "tstwg",
+						"max_nodes":         "3",
+					}),
 
 					// Verify fields has been removed
-					resource.TestCheckNoResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.annotations.test"),
-					resource.TestCheckNoResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.labels.tftestlabel"),
+					testCheckWorkerGroupNoAttr("cleura_shoot_cluster.test", "tstwg", "annotations.test"),
+					testCheckWorkerGroupNoAttr("cleura_shoot_cluster.test", "tstwg", "labels.tftestlabel"),
 
 					// Check the second worker group exists and properties are set
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.1.worker_group_name", "newwg"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.1.annotations.annotatontest", "annotationtestvalue"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.1.labels.labeltest", "labeltestvalue"),
-					// resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.1.taints.#", "1"),
-					// resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.1.taints.0.key", "taittest"),
-					// resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.1.taints.0.value", "tainttestvalue"),
-					// resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.1.taints.0.effect", "NoSchedule"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.1.zones.#", "1"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.1.zones.0", "nova"),
+					resource.TestCheckTypeSetElemNestedAttrs("cleura_shoot_cluster.test", "provider_details.worker_groups.*", map[string]string{
+						"worker_group_name":         "newwg",
+						"annotations.annotatontest": "annotationtestvalue",
+						"labels.labeltest":          "labeltestvalue",
+						"zones.#":                   "1",
+						"zones.0":                   "nova",
+					}),
 
 					// Verify updated maintenance config
 					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "maintenance.auto_update_kubernetes", "false"),
@@ -89,20 +105,24 @@ func TestAccShootResource(t *testing.T) {
 					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "hibernation_schedules.#", "1"),
 					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "hibernation_schedules.0.start", "00 18 * * 1,2,3,4,5"),
 					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "hibernation_schedules.0.end", "00 08 * * 1,2,3,4,5"),
+					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "hibernation_schedules.0.location", "Europe/Stockholm"),
 				),
 			},
 			{
 				ConfigDirectory: config.TestStepDirectory(),
 				ConfigVariables: varsTest,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.annotations.annotationsetfromupdate", "defg"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.labels.labelsetfromupdate", "hijk"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.taints.#", "1"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.taints.0.key", "taintsetfromupdate"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.taints.0.value", "789"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.taints.0.effect", "NoSchedule"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.zones.#", "1"),
-					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.0.zones.0", "nova"),
+					resource.TestCheckTypeSetElemNestedAttrs("cleura_shoot_cluster.test", "provider_details.worker_groups.*", map[string]string{
+						"worker_group_name":                   "tstwg",
+						"annotations.annotationsetfromupdate": "defg",
+						"labels.labelsetfromupdate":           "hijk",
+						"taints.#":                            "1",
+						"taints.0.key":                        "taintsetfromupdate",
+						"taints.0.value":                      "789",
+						"taints.0.effect":                     "NoSchedule",
+						"zones.#":                             "1",
+						"zones.0":                             "nova",
+					}),
 
 					// Verify only one worker group exists
 					resource.TestCheckResourceAttr("cleura_shoot_cluster.test", "provider_details.worker_groups.#", "1"),
@@ -118,3 +138,25 @@ func TestAccShootResource(t *testing.T) {
 		},
 	})
 }
+
+// testCheckWorkerGroupNoAttr checks that the worker group with the given name has no value for key.
+func testCheckWorkerGroupNoAttr(resourceName string, workerGroupName string, key string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		attributes := rs.Primary.Attributes
+		for k, v := range attributes {
+			prefix, found := strings.CutSuffix(k, ".worker_group_name")
+			if !found || v != workerGroupName || !strings.HasPrefix(prefix, "provider_details.worker_groups.") {
+				continue
+			}
+			if value, ok := attributes[prefix+"."+key]; ok {
+				return fmt.Errorf("worker group %s: expected no %s, got %q", workerGroupName, key, value)
+			}
+			return nil
+		}
+		return fmt.Errorf("worker group %s not found in %s", workerGroupName, resourceName)
+	}
+}
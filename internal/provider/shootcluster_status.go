@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// shootClusterDetails is a shoot cluster response extended with status fields the Cleura API client does not decode.
+type shootClusterDetails struct {
+	*cleura.ShootClusterResponse
+	LastOperation shootLastOperation
+	LastErrors    []shootLastError
+}
+
+type shootLastOperation struct {
+	Description    string `json:"description"`
+	LastUpdateTime string `json:"lastUpdateTime"`
+}
+
+type shootLastError struct {
+	Description string   `json:"description"`
+	TaskID      string   `json:"taskID"`
+	Codes       []string `json:"codes"`
+}
+
+// GetShootClusterDetails gets the shoot cluster and decodes the last operation description and last errors
+// from the same response.
+func (c *apiClient) GetShootClusterDetails(ctx context.Context, gardenDomain string, clusterName string, clusterRegion string, clusterProject string) (*shootClusterDetails, error) {
+	recorder := &responseBodyRecorder{}
+	client := withTransport(c.client, func(transport http.RoundTripper) http.RoundTripper {
+		recorder.transport = transport
+		return recorder
+	})
+
+	recordingClient := &apiClient{client: client, retry: c.retry}
+	shoot, err := recordingClient.GetShootCluster(ctx, gardenDomain, clusterName, clusterRegion, clusterProject)
+	if err != nil {
+		return nil, err
+	}
+
+	var status struct {
+		Status struct {
+			LastOperation shootLastOperation `json:"lastOperation"`
+			LastErrors    []shootLastError   `json:"lastErrors"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(recorder.body, &status); err != nil {
+		return nil, err
+	}
+	return &shootClusterDetails{
+		ShootClusterResponse: shoot,
+		LastOperation:        status.Status.LastOperation,
+		LastErrors:           status.Status.LastErrors,
+	}, nil
+}
+
+// responseBodyRecorder is an http.RoundTripper keeping a copy of the body of the last response.
+type responseBodyRecorder struct {
+	transport http.RoundTripper
+	body      []byte
+}
+
+func (r *responseBodyRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.body = body
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// lastOperationFailed reports whether the last operation of the shoot cluster failed permanently.
+func lastOperationFailed(state string) bool {
+	return state == "Failed" || state == "Error" || state == "Aborted"
+}
+
+// operationFailedError is returned by the waiters when the last operation of the shoot cluster failed.
+type operationFailedError struct {
+	shoot *shootClusterDetails
+}
+
+func (e *operationFailedError) Error() string {
+	lastOperation := e.shoot.Status.LastOperation
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s operation of the shoot cluster ended in state %s", lastOperation.Type, lastOperation.State)
+	if e.shoot.LastOperation.Description != "" {
+		fmt.Fprintf(&b, ": %s", e.shoot.LastOperation.Description)
+	}
+	for _, lastError := range e.shoot.LastErrors {
+		fmt.Fprintf(&b, "\n\nError: %s", lastError.Description)
+		if len(lastError.Codes) > 0 {
+			fmt.Fprintf(&b, " (codes: %s)", strings.Join(lastError.Codes, ", "))
+		}
+	}
+	for _, cond := range e.shoot.Status.Conditions {
+		if cond.Status != "True" {
+			fmt.Fprintf(&b, "\n\nCondition %s is %s: %s", cond.Type, cond.Status, cond.Message)
+		}
+	}
+	return b.String()
+}
+
+// logOperationProgress logs the last operation and the conditions of the shoot cluster on each poll of the waiters.
+func logOperationProgress(ctx context.Context, shoot *shootClusterDetails) {
+	conditions := make([]string, 0, len(shoot.Status.Conditions))
+	for _, cond := range shoot.Status.Conditions {
+		conditions = append(conditions, cond.Type+"="+cond.Status)
+	}
+	tflog.Info(ctx, "Shoot cluster operation progress", map[string]any{
+		"name":        shoot.Metadata.Name,
+		"type":        shoot.Status.LastOperation.Type,
+		"state":       shoot.Status.LastOperation.State,
+		"progress":    shoot.Status.LastOperation.Progress,
+		"description": shoot.LastOperation.Description,
+		"conditions":  strings.Join(conditions, ", "),
+	})
+}
+
+type lastOperationModel struct {
+	Type           types.String `tfsdk:"type"`
+	State          types.String `tfsdk:"state"`
+	Progress       types.Int64  `tfsdk:"progress"`
+	Description    types.String `tfsdk:"description"`
+	LastUpdateTime types.String `tfsdk:"last_update_time"`
+}
+
+func lastOperationAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"type":             types.StringType,
+		"state":            types.StringType,
+		"progress":         types.Int64Type,
+		"description":      types.StringType,
+		"last_update_time": types.StringType,
+	}
+}
+
+func lastOperationObjectValue(ctx context.Context, shoot *shootClusterDetails) (types.Object, diag.Diagnostics) {
+	return types.ObjectValueFrom(ctx, lastOperationAttrTypes(), lastOperationModel{
+		Type:           types.StringValue(shoot.Status.LastOperation.Type),
+		State:          types.StringValue(shoot.Status.LastOperation.State),
+		Progress:       types.Int64Value(int64(shoot.Status.LastOperation.Progress)),
+		Description:    types.StringValue(shoot.LastOperation.Description),
+		LastUpdateTime: types.StringValue(shoot.LastOperation.LastUpdateTime),
+	})
+}
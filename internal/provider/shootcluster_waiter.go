@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/cenkalti/backoff/v4"
+)
+
+// errOperationPending is returned by waiter checks while the awaited state is not reached yet.
+var errOperationPending = errors.New("operation is not finished yet")
+
+// errClusterHibernated is returned by clusterAPIServerWaiter when the shoot cluster is hibernated, as its API
+// server does not become available until the cluster is woken up.
+var errClusterHibernated = errors.New("shoot cluster is hibernated")
+
+// apiServerConditionType is the type of the shoot cluster condition reporting whether the API server is available.
+const apiServerConditionType = "APIServerAvailable"
+
+// clusterWaiterCheck inspects the shoot cluster, or the error of getting it, on each poll. It returns
+// errOperationPending to keep polling, nil when the awaited state is reached and any other error to stop.
+type clusterWaiterCheck func(shoot *shootClusterDetails, err error) error
+
+// clusterOperationWaiter polls the shoot cluster on the polling schedule until check reports the awaited
+// state is reached, check fails or ctx is done.
+func clusterOperationWaiter(client *apiClient, ctx context.Context, polling pollingSchedule, key clusterKey, waitingFor string, check clusterWaiterCheck) error {
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for the shoot cluster %s: %w", waitingFor, ctx.Err())
+	case <-time.After(polling.initialDelay):
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = polling.interval
+	b.MaxInterval = polling.maxInterval
+	b.RandomizationFactor = 0
+	b.MaxElapsedTime = 0
+
+	operation := func() error {
+		shoot, err := client.GetShootClusterDetails(ctx, key.gardenerDomain, key.name, key.region, key.project)
+		if shoot != nil {
+			logOperationProgress(ctx, shoot)
+		}
+		err = check(shoot, err)
+		if err != nil && !errors.Is(err, errOperationPending) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+	err := backoff.Retry(operation, backoff.WithContext(b, ctx))
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("timed out waiting for the shoot cluster %s: %w", waitingFor, ctx.Err())
+	}
+	return err
+}
+
+// checkOperationFailed returns an operationFailedError if the last operation of the shoot cluster failed.
+func checkOperationFailed(shoot *shootClusterDetails) error {
+	if lastOperationFailed(shoot.Status.LastOperation.State) {
+		return &operationFailedError{shoot: shoot}
+	}
+	return nil
+}
+
+// clusterReadyWaiter waits until all conditions of the shoot cluster are true.
+func clusterReadyWaiter(client *apiClient, ctx context.Context, polling pollingSchedule, key clusterKey) error {
+	return clusterOperationWaiter(client, ctx, polling, key, "to become ready", func(shoot *shootClusterDetails, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := checkOperationFailed(shoot); err != nil {
+			return err
+		}
+		if len(shoot.Status.Conditions) < 1 {
+			return errOperationPending
+		}
+		for _, cond := range shoot.Status.Conditions {
+			if cond.Status != "True" {
+				return errOperationPending
+			}
+		}
+		return nil
+	})
+}
+
+// operationUpdatedSince reports whether the last operation of the shoot cluster was updated after since, the
+// last update time of the last operation returned by clusterIdleWaiter before a request was sent. Until then,
+// the last operation is the one before the request, which must not be mistaken for the operation started by
+// the request. Times that can not be compared are only checked for a change, and all operations are accepted
+// if the last update time before the request is unknown.
+func operationUpdatedSince(shoot *shootClusterDetails, since string) bool {
+	if since == "" {
+		return true
+	}
+	updated, err := time.Parse(time.RFC3339, shoot.LastOperation.LastUpdateTime)
+	if err != nil {
+		return shoot.LastOperation.LastUpdateTime != since
+	}
+	before, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return shoot.LastOperation.LastUpdateTime != since
+	}
+	return updated.After(before)
+}
+
+// clusterReconcileWaiter waits until the create or reconcile operation started after since succeeded.
+func clusterReconcileWaiter(client *apiClient, ctx context.Context, polling pollingSchedule, key clusterKey, since string) error {
+	return clusterOperationWaiter(client, ctx, polling, key, "to be reconciled", func(shoot *shootClusterDetails, err error) error {
+		if err != nil {
+			return err
+		}
+		if !operationUpdatedSince(shoot, since) {
+			return errOperationPending
+		}
+		if err := checkOperationFailed(shoot); err != nil {
+			return err
+		}
+		lastOperation := shoot.Status.LastOperation
+		if lastOperation.State == "Succeeded" && (lastOperation.Type == "Create" || lastOperation.Type == "Reconcile") {
+			return nil
+		}
+		return errOperationPending
+	})
+}
+
+// deleteClusterWaiter waits until the API responds with 404 for the shoot cluster. It fails only if the delete
+// operation started after since failed, not for an earlier failed operation still reported by the cluster.
+func deleteClusterWaiter(client *apiClient, ctx context.Context, polling pollingSchedule, key clusterKey, since string) error {
+	return clusterOperationWaiter(client, ctx, polling, key, "to be deleted", func(shoot *shootClusterDetails, err error) error {
+		if err != nil {
+			var re *cleura.RequestAPIError
+			if errors.As(err, &re) && re.StatusCode == 404 {
+				return nil
+			}
+			return err
+		}
+		if shoot.Status.LastOperation.Type != "Delete" || !operationUpdatedSince(shoot, since) {
+			return errOperationPending
+		}
+		if err := checkOperationFailed(shoot); err != nil {
+			return err
+		}
+		return errOperationPending
+	})
+}
+
+// clusterIdleWaiter waits until the last operation of the cluster is no longer in progress, so a new
+// mutation is not rejected because of an operation started outside of this provider process. It returns the
+// last update time of the last operation, to wait for the operation started by the mutation afterwards.
+func clusterIdleWaiter(client *apiClient, ctx context.Context, polling pollingSchedule, key clusterKey) (string, error) {
+	// The cluster is checked right away, there is no operation started by the provider to wait for.
+	polling.initialDelay = 0
+	var lastUpdateTime string
+	err := clusterOperationWaiter(client, ctx, polling, key, "operation to finish", func(shoot *shootClusterDetails, err error) error {
+		if err != nil {
+			return err
+		}
+		if shoot.Status.LastOperation.State == "Processing" || shoot.Status.LastOperation.State == "Pending" {
+			return errOperationPending
+		}
+		lastUpdateTime = shoot.LastOperation.LastUpdateTime
+		return nil
+	})
+	return lastUpdateTime, err
+}
+
+// clusterHibernationWaiter waits until the shoot cluster is hibernated or woken up, as requested by hibernated,
+// by an operation started after since.
+func clusterHibernationWaiter(client *apiClient, ctx context.Context, polling pollingSchedule, key clusterKey, hibernated bool, since string) error {
+	waitingFor := "to wake up"
+	if hibernated {
+		waitingFor = "to hibernate"
+	}
+	return clusterOperationWaiter(client, ctx, polling, key, waitingFor, func(shoot *shootClusterDetails, err error) error {
+		if err != nil {
+			return err
+		}
+		if !operationUpdatedSince(shoot, since) {
+			return errOperationPending
+		}
+		if err := checkOperationFailed(shoot); err != nil {
+			return err
+		}
+		if shoot.Status.Hibernated == hibernated && shoot.Status.LastOperation.State == "Succeeded" {
+			return nil
+		}
+		return errOperationPending
+	})
+}
+
+// apiServerCondition returns the APIServerAvailable condition of the shoot cluster, if it is reported.
+func apiServerCondition(shoot *cleura.ShootClusterResponse) (cleura.Condition, bool) {
+	for _, cond := range shoot.Status.Conditions {
+		if cond.Type == apiServerConditionType {
+			return cond, true
+		}
+	}
+	return cleura.Condition{}, false
+}
+
+// clusterAPIServerWaiter waits until the API server of the shoot cluster is available.
+func clusterAPIServerWaiter(client *apiClient, ctx context.Context, polling pollingSchedule, key clusterKey) error {
+	// The cluster is checked right away, there is no operation started by the provider to wait for.
+	polling.initialDelay = 0
+	return clusterOperationWaiter(client, ctx, polling, key, "API server to become available", func(shoot *shootClusterDetails, err error) error {
+		if err != nil {
+			return err
+		}
+		if shoot.Status.Hibernated {
+			return errClusterHibernated
+		}
+		if err := checkOperationFailed(shoot); err != nil {
+			return err
+		}
+		if cond, ok := apiServerCondition(shoot.ShootClusterResponse); ok && cond.Status == "True" {
+			return nil
+		}
+		return errOperationPending
+	})
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+)
+
+func TestOperationUpdatedSince(t *testing.T) {
+	tests := []struct {
+		name           string
+		lastUpdateTime string
+		since          string
+		expected       bool
+	}{
+		{name: "operation before the request", lastUpdateTime: "2024-06-01T12:00:00Z", since: "2024-06-01T12:00:00Z", expected: false},
+		{name: "operation after the request", lastUpdateTime: "2024-06-01T12:00:05Z", since: "2024-06-01T12:00:00Z", expected: true},
+		{name: "older operation", lastUpdateTime: "2024-06-01T11:00:00Z", since: "2024-06-01T12:00:00Z", expected: false},
+		{name: "unknown time before the request", lastUpdateTime: "2024-06-01T12:00:00Z", since: "", expected: true},
+		{name: "invalid time changed", lastUpdateTime: "later", since: "now", expected: true},
+		{name: "invalid time unchanged", lastUpdateTime: "now", since: "now", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shoot := &shootClusterDetails{LastOperation: shootLastOperation{LastUpdateTime: tt.lastUpdateTime}}
+			if got := operationUpdatedSince(shoot, tt.since); got != tt.expected {
+				t.Fatalf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDeleteClusterWaiter(t *testing.T) {
+	const since = "2024-06-01T12:00:00Z"
+	operation := func(operationType string, state string, lastUpdateTime string) string {
+		return `{"metadata": {"name": "test"}, "status": {"lastOperation": {"type": "` + operationType + `", "state": "` + state + `", "lastUpdateTime": "` + lastUpdateTime + `"}}}`
+	}
+	tests := []struct {
+		name        string
+		responses   []string
+		expectError bool
+	}{
+		{name: "deleted", responses: []string{operation("Delete", "Processing", "2024-06-01T12:00:05Z")}},
+		{name: "earlier reconcile failed", responses: []string{operation("Reconcile", "Failed", since)}},
+		{name: "earlier delete failed", responses: []string{operation("Delete", "Failed", since)}},
+		{name: "delete failed", responses: []string{operation("Delete", "Processing", "2024-06-01T12:00:05Z"), operation("Delete", "Failed", "2024-06-01T12:00:10Z")}, expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				defer func() { polls++ }()
+				if polls >= len(tt.responses) {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write([]byte(tt.responses[polls]))
+			}))
+			defer server.Close()
+
+			c := &apiClient{
+				client: &cleura.Client{HostURL: server.URL, HTTPClient: server.Client()},
+				retry:  retryPolicy{maxAttempts: 1},
+			}
+			polling := pollingSchedule{interval: time.Millisecond, maxInterval: time.Millisecond}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			err := deleteClusterWaiter(c, ctx, polling, clusterKey{gardenerDomain: "public", region: "sto2", project: "project-id", name: "test"}, since)
+			if !tt.expectError {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			var failed *operationFailedError
+			if !errors.As(err, &failed) {
+				t.Fatalf("expected operation failed error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,640 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aztekas/cleura-client-go/pkg/api/cleura"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &shootWorkerGroupResource{}
+	_ resource.ResourceWithConfigure      = &shootWorkerGroupResource{}
+	_ resource.ResourceWithValidateConfig = &shootWorkerGroupResource{}
+	_ resource.ResourceWithImportState    = &shootWorkerGroupResource{}
+	_ resource.ResourceWithModifyPlan     = &shootWorkerGroupResource{}
+)
+
+// NewShootWorkerGroupResource is a helper function to simplify the provider implementation.
+func NewShootWorkerGroupResource() resource.Resource {
+	return &shootWorkerGroupResource{}
+}
+
+// shootWorkerGroupResource is the resource implementation.
+type shootWorkerGroupResource struct {
+	client   *apiClient
+	defaults providerDefaults
+}
+
+type shootWorkerGroupResourceModel struct {
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
+	ClusterName            types.String   `tfsdk:"cluster_name"`
+	GardenerDomain         types.String   `tfsdk:"gardener_domain"`
+	Project                types.String   `tfsdk:"project"`
+	Region                 types.String   `tfsdk:"region"`
+	WorkerGroupName        types.String   `tfsdk:"worker_group_name"`
+	MachineType            types.String   `tfsdk:"machine_type"`
+	ImageName              types.String   `tfsdk:"image_name"`
+	ImageVersion           types.String   `tfsdk:"image_version"`
+	ImageVersionConstraint types.String   `tfsdk:"image_version_constraint"`
+	ImageClassification    types.String   `tfsdk:"image_version_classification"`
+	AllowPreviewVersions   types.Bool     `tfsdk:"allow_preview_versions"`
+	VolumeSize             types.String   `tfsdk:"worker_node_volume_size"`
+	MinNodes               types.Int64    `tfsdk:"min_nodes"`
+	MaxNodes               types.Int64    `tfsdk:"max_nodes"`
+	Annotations            types.Map      `tfsdk:"annotations"`
+	Labels                 types.Map      `tfsdk:"labels"`
+	Taints                 types.List     `tfsdk:"taints"`
+	Zones                  types.List     `tfsdk:"zones"`
+	Polling                *pollingModel  `tfsdk:"polling"`
+}
+
+func (m shootWorkerGroupResourceModel) clusterKey() clusterKey {
+	return clusterKey{
+		gardenerDomain: m.GardenerDomain.ValueString(),
+		region:         m.Region.ValueString(),
+		project:        m.Project.ValueString(),
+		name:           m.ClusterName.ValueString(),
+	}
+}
+
+// workerGroup returns the worker group part of the model, as used by the cluster resource.
+func (m shootWorkerGroupResourceModel) workerGroup() workerGroupModelV1 {
+	return workerGroupModelV1{
+		WorkerGroupName:        m.WorkerGroupName,
+		MachineType:            m.MachineType,
+		ImageName:              m.ImageName,
+		ImageVersion:           m.ImageVersion,
+		ImageVersionConstraint: m.ImageVersionConstraint,
+		ImageClassification:    m.ImageClassification,
+		VolumeSize:             m.VolumeSize,
+		MinNodes:               m.MinNodes,
+		MaxNodes:               m.MaxNodes,
+		Annotations:            m.Annotations,
+		Labels:                 m.Labels,
+		Taints:                 m.Taints,
+		Zones:                  m.Zones,
+	}
+}
+
+// setWorkerGroup sets the worker group attributes from the worker group of the API response.
+func (m *shootWorkerGroupResourceModel) setWorkerGroup(ctx context.Context, worker cleura.WorkerUpdateResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.WorkerGroupName = types.StringValue(worker.Name)
+	m.MachineType = types.StringValue(worker.Machine.Type)
+	m.ImageName = types.StringValue(worker.Machine.Image.Name)
+	m.ImageVersion = types.StringValue(worker.Machine.Image.Version)
+	m.VolumeSize = types.StringValue(worker.Volume.Size)
+	m.MinNodes = types.Int64Value(int64(worker.Minimum))
+	m.MaxNodes = types.Int64Value(int64(worker.Maximum))
+
+	// Empty annotations, labels and taints are kept null to match an unset configuration
+	var d diag.Diagnostics
+	m.Annotations = types.MapNull(types.StringType)
+	if len(worker.Annotations) > 0 {
+		m.Annotations, d = types.MapValueFrom(ctx, types.StringType, worker.Annotations)
+		diags.Append(d...)
+	}
+	m.Labels = types.MapNull(types.StringType)
+	if len(worker.Labels) > 0 {
+		m.Labels, d = types.MapValueFrom(ctx, types.StringType, worker.Labels)
+		diags.Append(d...)
+	}
+	m.Taints = types.ListNull(types.ObjectType{AttrTypes: taintAttrTypesV0()})
+	if len(worker.Taints) > 0 {
+		m.Taints, d = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: taintAttrTypesV0()}, cleuraTaintListToTaintList(worker.Taints))
+		diags.Append(d...)
+	}
+	m.Zones, d = types.ListValueFrom(ctx, types.StringType, worker.Zones)
+	diags.Append(d...)
+	return diags
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *shootWorkerGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*cleuraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cleuraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = providerData.client
+	r.defaults = providerData.defaults
+}
+
+func (r *shootWorkerGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config shootWorkerGroupResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validatePollingConfig(config.Polling, path.Root("polling"), &resp.Diagnostics)
+	validateWorkerGroupName(config.WorkerGroupName, path.Root("worker_group_name"), &resp.Diagnostics)
+}
+
+// Metadata returns the resource type name.
+func (r *shootWorkerGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shoot_worker_group"
+}
+
+// Schema defines the schema for the resource.
+func (r *shootWorkerGroupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a worker group of a shoot cluster outside of the `cleura_shoot_cluster` resource. " +
+			"The worker group must not be listed in `provider_details.worker_groups` of the cluster, and the cluster must set `ignore_unmanaged_worker_groups`.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+				Update: true,
+			}),
+			"cluster_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the shoot cluster the worker group belongs to. Requires replace if modified.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"gardener_domain": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Gardener domain. Defaults to the provider default_gardener_domain, which defaults to 'public'. Requires replace if modified.",
+			},
+			"project": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the project of the cluster. Defaults to the provider default_project. Requires replace if modified.",
+			},
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Region of the cluster. Defaults to the provider default_region. Requires replace if modified.",
+			},
+			"worker_group_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Worker group name. Max 6 lowercase alphanumeric characters. Requires replace if modified.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"min_nodes": schema.Int64Attribute{
+				Required:    true,
+				Description: "The minimum number of worker nodes in the worker group.",
+			},
+			"max_nodes": schema.Int64Attribute{
+				Required:    true,
+				Description: "The maximum number of worker nodes in the worker group",
+			},
+			"machine_type": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the desired type/flavor of the worker nodes",
+			},
+			"image_name": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: "The name of the image of the worker nodes",
+				Default:     stringdefault.StaticString("gardenlinux"),
+			},
+			"image_version": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: "The version of the image of the worker nodes. Defaults to the current version of the worker group, or the latest supported version for new worker groups and when `image_name` changes.",
+			},
+			"image_version_classification": schema.StringAttribute{
+				Computed:    true,
+				Description: "Classification of `image_version` in the cloud profile when it was selected, e.g. 'supported' or 'preview'.",
+			},
+			"allow_preview_versions": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Allow selecting preview versions when the image version is not set explicitly, or is resolved from a constraint. Only supported versions are selected by default.",
+			},
+			"image_version_constraint": schema.StringAttribute{
+				Optional:    true,
+				Description: "Version constraint, e.g. '~> 1443.3', resolved to the highest matching supported version of the image. The current version is kept while it matches. Conflicts with `image_version`.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("image_version")),
+				},
+			},
+			"worker_node_volume_size": schema.StringAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: "The desired size of the volume used for the worker nodes. Example '50Gi'",
+				Default:     stringdefault.StaticString("50Gi"),
+			},
+			"annotations": schema.MapAttribute{
+				Optional:    true,
+				Description: "Annotations for worker nodes",
+				ElementType: types.StringType,
+			},
+			"labels": schema.MapAttribute{
+				Optional:    true,
+				Description: "Labels for worker nodes",
+				ElementType: types.StringType,
+			},
+			"taints": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "Taints for worker nodes",
+				CustomType:  types.ListType{ElemType: types.ObjectType{AttrTypes: taintAttrTypesV0()}},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Required:    true,
+							Description: "Key name for taint. Must adhere to Kubernetes key naming specifications",
+						},
+						"value": schema.StringAttribute{
+							Required:    true,
+							Description: "Value for taint. Must be within Kubernetes taint value specifications",
+						},
+						"effect": schema.StringAttribute{
+							Required:    true,
+							Description: "Effect for taint. Possible values are 'NoExecute', 'NoSchedule' and 'PreferNoSchedule'",
+							Validators:  []validator.String{stringvalidator.OneOf("NoSchedule", "NoExecute", "PreferNoSchedule")},
+						},
+					},
+				},
+			},
+			"zones": schema.ListAttribute{
+				Computed:    true,
+				Optional:    true,
+				Description: "List of availability zones worker nodes can be scheduled in. Defaults to all zones of the region.",
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"polling": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Configure how often the cluster is polled while waiting for an operation to finish. Defaults to the provider polling configuration.",
+				Attributes: map[string]schema.Attribute{
+					"initial_delay": schema.StringAttribute{
+						Optional:    true,
+						Description: pollingAttributeDescriptions["initial_delay"],
+					},
+					"interval": schema.StringAttribute{
+						Optional:    true,
+						Description: pollingAttributeDescriptions["interval"],
+					},
+					"max_interval": schema.StringAttribute{
+						Optional:    true,
+						Description: pollingAttributeDescriptions["max_interval"],
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *shootWorkerGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// No plan modification is needed if destroying the resource
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan shootWorkerGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.defaults.planLocation(ctx, req, resp, &plan.Project, &plan.Region, &plan.GardenerDomain)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, err := r.client.GetCloudProfile(ctx, plan.GardenerDomain.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to get profile data",
+			err.Error(),
+		)
+		return
+	}
+
+	var state shootWorkerGroupResourceModel
+	exists := !req.State.Raw.IsNull()
+	if exists {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var configImageVersion types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("image_version"), &configImageVersion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resolve the image version constraint, or keep the current version of the image if not set explicitly.
+	// The latest version is used for new worker groups and when the image changes. Only supported versions
+	// are selected unless preview versions are allowed.
+	imageVersions := machineImageVersions(profile, plan.ImageName.ValueString())
+	allowPreview := plan.AllowPreviewVersions.ValueBool()
+	if constraint := plan.ImageVersionConstraint; !constraint.IsNull() && !constraint.IsUnknown() {
+		resolved, err := resolveVersionConstraint(imageVersions, constraint.ValueString(), state.ImageVersion, allowPreview)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("image_version_constraint"), "Invalid Image Version Constraint", err.Error())
+			return
+		}
+		plan.ImageVersion = types.StringValue(resolved)
+	} else if configImageVersion.IsNull() && !constraint.IsUnknown() && !plan.ImageName.IsUnknown() {
+		if exists && plan.ImageName.Equal(state.ImageName) {
+			plan.ImageVersion = state.ImageVersion
+		} else {
+			latest, err := getLatestVersion(imageVersions, allowPreview)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("image_version"), "Unable to Select Image Version",
+					fmt.Sprintf("Image %q: %s", plan.ImageName.ValueString(), err))
+				return
+			}
+			plan.ImageVersion = types.StringValue(latest)
+		}
+	}
+
+	// Report the classification of the selected image version, kept while the version is unchanged
+	if exists && plan.ImageName.Equal(state.ImageName) && plan.ImageVersion.Equal(state.ImageVersion) {
+		plan.ImageClassification = state.ImageClassification
+	} else if !plan.ImageVersion.IsUnknown() {
+		plan.ImageClassification = versionClassification(imageVersions, plan.ImageVersion)
+	}
+
+	// Use all availability zones in the region if not set explicitly
+	if plan.Zones.IsUnknown() && !plan.Region.IsUnknown() {
+		zones, diags := types.ListValueFrom(ctx, types.StringType, regionZones(profile, plan.Region.ValueString()))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.Zones = zones
+	}
+
+	// Validate the values that change against the cloud profile, values in the state may have been
+	// removed from the cloud profile since they were applied
+	if !exists || !plan.MachineType.Equal(state.MachineType) {
+		validateMachineType(profile, path.Root("machine_type"), plan.MachineType, &resp.Diagnostics)
+	}
+	if !exists || !plan.ImageName.Equal(state.ImageName) || !plan.ImageVersion.Equal(state.ImageVersion) {
+		validateMachineImage(profile, path.Empty(), plan.ImageName, plan.ImageVersion, &resp.Diagnostics)
+	}
+	if !exists || !plan.Zones.Equal(state.Zones) {
+		validateZones(profile, path.Root("zones"), plan.Region, plan.Zones, &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// Create creates the worker group and sets the initial Terraform state.
+func (r *shootWorkerGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan shootWorkerGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	createTimeout, diags := plan.Timeouts.Create(ctx, 45*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	workerGroupRequest, diags := createWorkerRequestV1(ctx, plan.workerGroup())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.mutate(ctx, plan, &resp.Diagnostics, "Error Adding Worker Group", func(key clusterKey) error {
+		_, err := r.client.AddWorkerGroup(ctx, key.gardenerDomain, key.name, key.region, key.project, cleura.WorkerGroupRequest{Worker: workerGroupRequest})
+		return err
+	})
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.refresh(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error Reading Worker Group",
+			fmt.Sprintf("Worker group %q was not found in shoot cluster %q after the request succeeded.", plan.WorkerGroupName.ValueString(), plan.ClusterName.ValueString()),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *shootWorkerGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state shootWorkerGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.refresh(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		resp.Diagnostics.AddWarning("Resource has been deleted outside terraform", "New resource will be created")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the worker group and sets the updated Terraform state on success.
+func (r *shootWorkerGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan shootWorkerGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 45*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	workerGroupRequest, diags := createWorkerRequestV1(ctx, plan.workerGroup())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.mutate(ctx, plan, &resp.Diagnostics, "API Error Updating Worker Group", func(key clusterKey) error {
+		_, err := r.client.UpdateWorkerGroup(ctx, key.gardenerDomain, key.name, key.region, key.project, plan.WorkerGroupName.ValueString(), cleura.WorkerGroupRequest{Worker: workerGroupRequest})
+		return err
+	})
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.refresh(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Error Reading Worker Group",
+			fmt.Sprintf("Worker group %q was not found in shoot cluster %q after the request succeeded.", plan.WorkerGroupName.ValueString(), plan.ClusterName.ValueString()),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the worker group and removes the Terraform state on success.
+func (r *shootWorkerGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state shootWorkerGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 45*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// A worker group, or cluster, deleted outside of Terraform is already gone
+	found := r.refresh(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || !found {
+		return
+	}
+
+	r.mutate(ctx, state, &resp.Diagnostics, "API Error Deleting Worker Group", func(key clusterKey) error {
+		_, err := r.client.DeleteWorkerGroup(ctx, key.gardenerDomain, key.name, key.region, key.project, state.WorkerGroupName.ValueString())
+		if isNotFound(err) {
+			return errWorkerGroupNotFound
+		}
+		return err
+	})
+}
+
+// ImportState imports a worker group by "GardenerDomain,ClusterName,Region,Project_id,WorkerGroupName".
+func (r *shootWorkerGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ",")
+	if len(idParts) != 5 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" || idParts[3] == "" || idParts[4] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: GardenerDomain,ClusterName,Region,Project_id,WorkerGroupName. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("gardener_domain"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_name"), idParts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("region"), idParts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), idParts[3])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("worker_group_name"), idParts[4])...)
+}
+
+// errWorkerGroupNotFound is returned by a mutate request when the worker group does not exist, e.g. as it was
+// deleted outside of Terraform.
+var errWorkerGroupNotFound = errors.New("worker group not found")
+
+// mutate sends a worker group request while holding the cluster operation lock and waits for the
+// cluster to be reconciled afterwards.
+func (r *shootWorkerGroupResource) mutate(ctx context.Context, model shootWorkerGroupResourceModel, diags *diag.Diagnostics, errorSummary string, request func(key clusterKey) error) {
+	polling := newPollingSchedule(model.Polling, r.defaults.polling, path.Root("polling"), diags)
+	if diags.HasError() {
+		return
+	}
+
+	key := model.clusterKey()
+	unlock, err := lockCluster(ctx, key)
+	if err != nil {
+		diags.AddError(errorSummary, "Could not acquire the shoot cluster operation lock: "+err.Error())
+		return
+	}
+	defer unlock()
+
+	since, err := clusterIdleWaiter(r.client, ctx, polling, key)
+	if err != nil {
+		diags.AddError(
+			"API Error while waiting for ongoing cluster operation to finish",
+			fmt.Sprintf("... details ... %s", err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Sending worker group request", map[string]any{"cluster": key.name, "worker_group": model.WorkerGroupName.ValueString()})
+	err = request(key)
+	if errors.Is(err, errWorkerGroupNotFound) {
+		// Nothing was changed, so there is no reconcile to wait for
+		return
+	}
+	if err != nil {
+		diags.AddError(errorSummary, fmt.Sprintf("... details ... %s", err))
+		return
+	}
+
+	err = clusterReconcileWaiter(r.client, ctx, polling, key, since)
+	if err != nil {
+		diags.AddError(
+			"API Error while waiting for cluster to become ready (modify)",
+			fmt.Sprintf("... details ... %s", err),
+		)
+	}
+}
+
+// refresh sets the worker group attributes of the model from the API. It reports false if the
+// cluster or the worker group does not exist.
+func (r *shootWorkerGroupResource) refresh(ctx context.Context, model *shootWorkerGroupResourceModel, diags *diag.Diagnostics) bool {
+	key := model.clusterKey()
+	shootResponse, err := r.client.GetShootCluster(ctx, key.gardenerDomain, key.name, key.region, key.project)
+	if err != nil {
+		if isNotFound(err) {
+			return false
+		}
+		diags.AddError(
+			"Error Reading Shoot cluster",
+			"Could not read Shoot cluster name "+key.name+": "+err.Error(),
+		)
+		return false
+	}
+
+	for _, worker := range shootResponse.Spec.Provider.Workers {
+		if worker.Name == model.WorkerGroupName.ValueString() {
+			diags.Append(model.setWorkerGroup(ctx, worker)...)
+			return true
+		}
+	}
+	return false
+}
@@ -40,8 +40,9 @@ resource "cleura_shoot_cluster" "test" {
   }
   hibernation_schedules = [
     {
-      start = "00 18 * * 1,2,3,4,5"
-      end   = "00 08 * * 1,2,3,4,5"
+      start    = "00 18 * * 1,2,3,4,5"
+      end      = "00 08 * * 1,2,3,4,5"
+      location = "Europe/Stockholm"
     },
   ]
   maintenance = {